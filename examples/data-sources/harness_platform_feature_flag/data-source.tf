@@ -0,0 +1,10 @@
+data "harness_platform_feature_flag" "example" {
+  identifier = "new_checkout"
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "production"
+}
+
+output "new_checkout_state" {
+  value = data.harness_platform_feature_flag.example.state
+}
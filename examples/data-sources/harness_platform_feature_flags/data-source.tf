@@ -0,0 +1,12 @@
+data "harness_platform_feature_flags" "stale" {
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "production"
+  tags       = ["team:checkout"]
+  stale      = true
+  permanent  = false
+}
+
+output "stale_flags" {
+  value = [for flag in data.harness_platform_feature_flags.stale.flags : flag.identifier]
+}
@@ -0,0 +1,5 @@
+data "harness_platform_ff_api_key" "example" {
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "testenv"
+}
@@ -0,0 +1,16 @@
+data "harness_platform_gitops_application_resources" "example" {
+  identifier = "identifier"
+  agent_id   = "agent_id"
+  org_id     = "org_id"
+  project_id = "project_id"
+  kinds      = ["Service", "Ingress"]
+
+  include_manifests = true
+  labels = {
+    "app.kubernetes.io/part-of" = "guestbook"
+  }
+}
+
+output "ingress_hosts" {
+  value = flatten([for r in data.harness_platform_gitops_application_resources.example.resources : r.external_urls])
+}
@@ -0,0 +1,10 @@
+data "harness_platform_gitops_repositories" "example" {
+  account_id = "account_id"
+  project_id = "project_id"
+  org_id     = "org_id"
+  agent_id   = "agent_id"
+}
+
+output "failed_repositories" {
+  value = [for r in data.harness_platform_gitops_repositories.example.repositories : r.repo if try(r.connection_state[0].status, "") != "Successful"]
+}
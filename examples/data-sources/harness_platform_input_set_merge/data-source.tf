@@ -0,0 +1,13 @@
+data "harness_platform_input_set_merge" "example" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+  input_set_ids = [
+    "defaults",
+    "production",
+  ]
+}
+
+output "unresolved_inputs" {
+  value = data.harness_platform_input_set_merge.example.unresolved_inputs
+}
@@ -0,0 +1,14 @@
+// Latest execution of a pipeline
+data "harness_platform_pipeline_execution" "latest" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+}
+
+// Specific execution of a pipeline
+data "harness_platform_pipeline_execution" "example" {
+  org_id       = "org_id"
+  project_id   = "project_id"
+  pipeline_id  = "pipeline_id"
+  execution_id = "execution_id"
+}
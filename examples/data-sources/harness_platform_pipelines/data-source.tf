@@ -0,0 +1,17 @@
+data "harness_platform_pipelines" "example" {
+  org_id       = "org_id"
+  project_id   = "project_id"
+  module       = "CD"
+  store_type   = "REMOTE"
+  template_ref = "account.deploy_template"
+  tags = [
+    "team:platform",
+  ]
+}
+
+output "last_execution_status" {
+  value = {
+    for pipeline in data.harness_platform_pipelines.example.pipelines :
+    pipeline.identifier => pipeline.last_execution_status
+  }
+}
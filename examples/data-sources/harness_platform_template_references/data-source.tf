@@ -0,0 +1,8 @@
+# Pipelines and templates of a project using version v1 of an org level
+# step template.
+data "harness_platform_template_references" "example" {
+  identifier          = "shell_step"
+  org_id              = "org_id"
+  version_label       = "v1"
+  consumer_project_id = "project_id"
+}
@@ -0,0 +1,2 @@
+# Import the state of a feature flag in an environment
+terraform import harness_platform_feature_flag_environment.example <org_id>/<project_id>/<env_id>/<flag_id>
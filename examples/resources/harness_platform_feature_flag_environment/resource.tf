@@ -0,0 +1,41 @@
+resource "harness_platform_feature_flag_environment" "example" {
+  identifier = harness_platform_feature_flag.mybooleanflag.identifier
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "production"
+
+  state = "on"
+
+  // Roll the feature out to 20% of the remaining targets
+  default_serve {
+    distribution {
+      bucket_by = "identifier"
+      variation {
+        variation = "Enabled"
+        weight    = 20
+      }
+      variation {
+        variation = "Disabled"
+        weight    = 80
+      }
+    }
+  }
+
+  // Serve the feature to everyone on the beta plan
+  rules {
+    serve {
+      variation = "Enabled"
+    }
+    clause {
+      attribute = "plan"
+      op        = "in"
+      values    = ["beta"]
+    }
+  }
+
+  target_rules {
+    variation     = "Enabled"
+    targets       = ["qa_user"]
+    target_groups = ["internal_users"]
+  }
+}
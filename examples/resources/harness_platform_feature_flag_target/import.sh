@@ -0,0 +1,2 @@
+# Import a feature flag target
+terraform import harness_platform_feature_flag_target.example <org_id>/<project_id>/<env_id>/<target_id>
@@ -0,0 +1,12 @@
+resource "harness_platform_feature_flag_target" "example" {
+  identifier = "qa_user"
+  name       = "QA User"
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "production"
+
+  attributes = {
+    email = "qa@example.com"
+    plan  = "beta"
+  }
+}
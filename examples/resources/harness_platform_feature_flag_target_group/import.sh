@@ -0,0 +1,2 @@
+# Import a feature flag target group
+terraform import harness_platform_feature_flag_target_group.example <org_id>/<project_id>/<env_id>/<target_group_id>
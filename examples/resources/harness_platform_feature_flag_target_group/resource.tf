@@ -0,0 +1,16 @@
+resource "harness_platform_feature_flag_target_group" "example" {
+  identifier = "internal_users"
+  name       = "Internal Users"
+  org_id     = "test"
+  project_id = "testff"
+  env_id     = "production"
+
+  included = [harness_platform_feature_flag_target.example.identifier]
+  excluded = ["contractor"]
+
+  rules {
+    attribute = "email"
+    op        = "ends_with"
+    values    = ["@example.com"]
+  }
+}
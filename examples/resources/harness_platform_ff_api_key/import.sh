@@ -0,0 +1,2 @@
+# Import a project level SDK API key
+terraform import harness_platform_ff_api_key.example <org_id>/<project_id>/<environment_id>/<api_key_id>
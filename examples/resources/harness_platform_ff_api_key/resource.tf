@@ -7,9 +7,12 @@ resource "harness_platform_ff_api_key" "testserverapikey" {
     env_id = "testenv"
     expired_at = 1713729225
     type = "Server"
+
+    # Changing this value creates a new key before the current one is deleted
+    rotate_trigger = "2024-01"
 }
 
 output "serversdkkey" {
   value = harness_platform_ff_api_key.testserverapikey.api_key
   sensitive = true
-}
\ No newline at end of file
+}
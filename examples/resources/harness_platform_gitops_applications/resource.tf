@@ -41,4 +41,9 @@ resource "harness_platform_gitops_applications" "example" {
   cluster_id = "cluster_id"
   repo_id    = "repo_id"
   agent_id   = "agent_id"
+
+  # Trigger a sync and wait until the application is Synced and Healthy
+  sync_on_apply   = true
+  wait_for_health = true
+  wait_timeout    = "15m"
 }
\ No newline at end of file
@@ -58,3 +58,31 @@ resource "harness_platform_gitops_cluster" "example" {
     ]
   }
 }
+
+# EKS cluster authenticated through IAM, without static credentials
+resource "harness_platform_gitops_cluster" "eks" {
+  identifier = "identifier"
+  account_id = "account_id"
+  project_id = "project_id"
+  org_id     = "org_id"
+  agent_id   = "agent_id"
+
+  request {
+    upsert = false
+    cluster {
+      server = "https://0123456789ABCDEF.gr7.us-east-1.eks.amazonaws.com"
+      name   = "eks-prod"
+      config {
+        tls_client_config {
+          ca_data = "base64-encoded-ca"
+        }
+        auth_preset {
+          provider         = "EKS"
+          aws_cluster_name = "eks-prod"
+          aws_role_arn     = "arn:aws:iam::123456789012:role/gitops-agent"
+        }
+        cluster_connection_type = "IRSA"
+      }
+    }
+  }
+}
@@ -0,0 +1,5 @@
+# Import Account level Gitops Repositories, given as a comma separated list of identifiers
+terraform import harness_platform_gitops_repositories.example <agent_id>/<repository_id>,<repository_id>
+
+# Import Project level Gitops Repositories, given as a comma separated list of identifiers
+terraform import harness_platform_gitops_repositories.example <organization_id>/<project_id>/<agent_id>/<repository_id>,<repository_id>
@@ -0,0 +1,17 @@
+// Register several repositories sharing the credentials of a repository credential template
+resource "harness_platform_gitops_repositories" "example" {
+  project_id      = "project_id"
+  org_id          = "org_id"
+  agent_id        = "agent_id"
+  repo_cred_id    = harness_platform_gitops_repo_cred.example.identifier
+  connection_type = "HTTPS"
+  upsert          = true
+
+  dynamic "repositories" {
+    for_each = toset(["service-a", "service-b", "service-c"])
+    content {
+      identifier = replace(repositories.value, "-", "_")
+      repo       = "https://github.com/example-org/${repositories.value}.git"
+    }
+  }
+}
@@ -50,3 +50,22 @@ resource "harness_platform_gitops_repository" "example" {
   }
   upsert = true
 }
+
+// Fail the apply when the agent cannot connect to the repository
+resource "harness_platform_gitops_repository" "example" {
+  identifier = "identifier"
+  account_id = "account_id"
+  project_id = "project_id"
+  org_id     = "org_id"
+  agent_id   = "agent_id"
+  repo {
+    repo                       = "https://github.com/example-org/private-repo.git"
+    name                       = "repo_name"
+    connection_type            = "GITHUB"
+    github_app_id              = "123456"
+    github_app_installation_id = "7891011"
+    github_app_private_key     = file("github-app.pem")
+  }
+  upsert                   = true
+  fail_on_connection_error = true
+}
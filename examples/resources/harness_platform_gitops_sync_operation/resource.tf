@@ -0,0 +1,21 @@
+// Sync a single deployment to a given revision whenever the change ticket changes
+resource "harness_platform_gitops_sync_operation" "example" {
+  account_id     = "account_id"
+  org_id         = "org_id"
+  project_id     = "project_id"
+  agent_id       = "agent_id"
+  application_id = "application_id"
+  revision       = "v1.2.3"
+  prune          = true
+
+  resources {
+    group     = "apps"
+    kind      = "Deployment"
+    name      = "guestbook-ui"
+    namespace = "guestbook"
+  }
+
+  triggers = {
+    change_ticket = "CHG-1234"
+  }
+}
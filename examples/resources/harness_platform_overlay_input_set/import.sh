@@ -0,0 +1,2 @@
+# Import overlay input set
+terraform import harness_platform_overlay_input_set.example <org_id>/<project_id>/<pipeline_id>/<overlay_input_set_id>
@@ -0,0 +1,17 @@
+resource "harness_platform_overlay_input_set" "example" {
+  identifier  = "identifier"
+  name        = "name"
+  description = "description"
+  tags = [
+    "foo:bar",
+  ]
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+
+  # Values from later input sets override those of earlier ones.
+  input_set_ids = [
+    "defaults",
+    "production",
+  ]
+}
@@ -0,0 +1,33 @@
+// Run the seed pipeline with input sets once the infrastructure exists
+resource "harness_platform_pipeline_execution" "seed" {
+  org_id              = "org_id"
+  project_id          = "project_id"
+  pipeline_id         = "seed"
+  input_set_ids       = ["defaults", "staging"]
+  wait_for_completion = true
+  wait_timeout        = "1h"
+
+  triggers = {
+    infrastructure = harness_platform_infrastructure.example.id
+  }
+}
+
+// Run a pipeline with inline runtime inputs
+resource "harness_platform_pipeline_execution" "example" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+
+  runtime_input_yaml = <<-EOT
+    pipeline:
+      identifier: pipeline_id
+      variables:
+        - name: version
+          type: String
+          value: 1.2.3
+  EOT
+
+  triggers = {
+    version = "1.2.3"
+  }
+}
@@ -0,0 +1,2 @@
+# Import pipeline notification rule
+terraform import harness_platform_pipeline_notification.example <org_id>/<project_id>/<pipeline_id>/<notification_rule_id>
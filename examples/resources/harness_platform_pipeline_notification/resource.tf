@@ -0,0 +1,44 @@
+resource "harness_platform_pipeline_notification" "example" {
+  identifier  = "failures"
+  name        = "failures"
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+
+  pipeline_events {
+    type = "PipelineFailed"
+  }
+  pipeline_events {
+    type       = "StageFailed"
+    for_stages = ["deploy"]
+  }
+
+  slack {
+    webhook_url_secret = "account.slack_webhook"
+    user_groups        = ["account.oncall"]
+  }
+}
+
+# Enforce the same rule across every pipeline of a project.
+data "harness_platform_pipelines" "all" {
+  org_id     = "org_id"
+  project_id = "project_id"
+}
+
+resource "harness_platform_pipeline_notification" "pagerduty" {
+  for_each = { for p in data.harness_platform_pipelines.all.pipelines : p.identifier => p }
+
+  identifier  = "pagerduty"
+  name        = "pagerduty"
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = each.key
+
+  pipeline_events {
+    type = "PipelineFailed"
+  }
+
+  pagerduty {
+    integration_key_secret = "account.pagerduty_key"
+  }
+}
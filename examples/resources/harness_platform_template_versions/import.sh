@@ -0,0 +1,8 @@
+# Import account level template versions
+terraform import harness_platform_template_versions.example <template_id>
+
+# Import org level template versions
+terraform import harness_platform_template_versions.example <org_id>/<template_id>
+
+# Import project level template versions
+terraform import harness_platform_template_versions.example <org_id>/<project_id>/<template_id>
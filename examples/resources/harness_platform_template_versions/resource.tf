@@ -0,0 +1,55 @@
+# Keeps v1 and v2 of the template, with v2 promoted to stable. Removing a
+# version from the list deletes it, unless pipelines still reference it.
+resource "harness_platform_template_versions" "example" {
+  identifier     = "shell_step"
+  org_id         = "org_id"
+  project_id     = "project_id"
+  stable_version = "v2"
+
+  versions {
+    version       = "v1"
+    template_yaml = <<-EOT
+      template:
+        name: shell_step
+        identifier: shell_step
+        versionLabel: v1
+        type: Step
+        projectIdentifier: project_id
+        orgIdentifier: org_id
+        spec:
+          type: ShellScript
+          timeout: 10m
+          spec:
+            shell: Bash
+            onDelegate: true
+            source:
+              type: Inline
+              spec:
+                script: echo v1
+    EOT
+  }
+
+  versions {
+    version       = "v2"
+    comments      = "Add a longer timeout"
+    template_yaml = <<-EOT
+      template:
+        name: shell_step
+        identifier: shell_step
+        versionLabel: v2
+        type: Step
+        projectIdentifier: project_id
+        orgIdentifier: org_id
+        spec:
+          type: ShellScript
+          timeout: 20m
+          spec:
+            shell: Bash
+            onDelegate: true
+            source:
+              type: Inline
+              spec:
+                script: echo v2
+    EOT
+  }
+}
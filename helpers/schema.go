@@ -3,6 +3,7 @@ package helpers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,6 +15,16 @@ func MergeSchemas(src map[string]*schema.Schema, dest map[string]*schema.Schema)
 	}
 }
 
+// ValidateDuration checks that a string attribute parses as a Go duration
+// such as "10m" or "1h30m".
+func ValidateDuration(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration such as \"10m\": %s", k, err))
+	}
+
+	return
+}
+
 func SetSchemaFlagType(s *schema.Schema, flag SchemaFlagType) {
 	switch flag {
 	case SchemaFlagTypes.Computed:
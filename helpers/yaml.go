@@ -0,0 +1,417 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runtimeInputExpression marks a field whose value is supplied when a
+// pipeline is run.
+const runtimeInputExpression = "<+input>"
+
+// ValidateEntityYaml checks that text is a YAML document whose root is a
+// single mapping under root, as in `pipeline:` or `inputSet:`, with an
+// identifier.
+func ValidateEntityYaml(text string, root string) error {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return err
+	}
+
+	entity := mappingValue(doc, root)
+	if entity == nil {
+		return fmt.Errorf("YAML must have a top level %q key", root)
+	}
+	if entity.Kind != yaml.MappingNode {
+		return fmt.Errorf("%q must be a mapping", root)
+	}
+	if identifier := mappingValue(entity, "identifier"); identifier == nil || identifier.Value == "" {
+		return fmt.Errorf("%q must have an identifier", root)
+	}
+	return nil
+}
+
+// RuntimeInputsTemplate returns the skeleton of the runtime inputs of an
+// entity YAML: only the fields set to `<+input>`, along with the identifiers
+// and types needed to locate them, so input sets can be generated from it.
+// It returns an empty string if the YAML has no runtime inputs.
+func RuntimeInputsTemplate(text string) (string, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return "", err
+	}
+
+	template, ok := pruneToRuntimeInputs(doc)
+	if !ok {
+		return "", nil
+	}
+	return encodeYaml(template)
+}
+
+// YamlEqual reports whether two YAML documents hold the same data, however
+// they are formatted. Documents that do not parse are compared as text.
+func YamlEqual(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if yaml.Unmarshal([]byte(a), &x) != nil || yaml.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func parseYamlMapping(text string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("YAML must be a mapping")
+	}
+	return doc.Content[0], nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// pruneToRuntimeInputs returns a copy of node holding only the branches
+// that lead to a runtime input, and whether there were any. Aliases are
+// resolved, so the copy has no anchors.
+func pruneToRuntimeInputs(node *yaml.Node) (*yaml.Node, bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		scalar := *node
+		scalar.Anchor = ""
+		return &scalar, strings.Contains(node.Value, runtimeInputExpression)
+	case yaml.SequenceNode:
+		pruned := &yaml.Node{Kind: yaml.SequenceNode, Tag: node.Tag}
+		for _, item := range node.Content {
+			if child, ok := pruneToRuntimeInputs(item); ok {
+				pruned.Content = append(pruned.Content, child)
+			}
+		}
+		return pruned, len(pruned.Content) > 0
+	case yaml.MappingNode:
+		pruned := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if child, ok := pruneToRuntimeInputs(node.Content[i+1]); ok {
+				pruned.Content = append(pruned.Content, node.Content[i], child)
+			}
+		}
+		if len(pruned.Content) == 0 {
+			return pruned, false
+		}
+		// Keep what identifies the element the inputs belong to. Variables
+		// are identified by name rather than identifier.
+		identifying := []string{"identifier", "type"}
+		if mappingValue(node, "identifier") == nil {
+			identifying = []string{"name", "type"}
+		}
+		var keys []*yaml.Node
+		for _, key := range identifying {
+			if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode && mappingValue(pruned, key) == nil {
+				keys = append(keys, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+			}
+		}
+		pruned.Content = append(keys, pruned.Content...)
+		return pruned, true
+	case yaml.AliasNode:
+		return pruneToRuntimeInputs(node.Alias)
+	}
+	return node, false
+}
+
+// TemplateReference is a use of a template within an entity YAML.
+type TemplateReference struct {
+	// TemplateRef is the template identifier, prefixed with `org.` or
+	// `account.` when the template belongs to a higher scope.
+	TemplateRef string
+	// VersionLabel is empty when the stable version is used.
+	VersionLabel string
+}
+
+// TemplateReferences returns the templates used by an entity YAML, in the
+// order they appear.
+func TemplateReferences(text string) ([]TemplateReference, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []TemplateReference
+	collectTemplateReferences(doc, &refs)
+	return refs, nil
+}
+
+func collectTemplateReferences(node *yaml.Node, refs *[]TemplateReference) {
+	if node.Kind == yaml.MappingNode {
+		if ref := mappingValue(node, "templateRef"); ref != nil && ref.Kind == yaml.ScalarNode {
+			reference := TemplateReference{TemplateRef: ref.Value}
+			if version := mappingValue(node, "versionLabel"); version != nil && version.Kind == yaml.ScalarNode {
+				reference.VersionLabel = version.Value
+			}
+			*refs = append(*refs, reference)
+		}
+	}
+	for _, child := range node.Content {
+		collectTemplateReferences(child, refs)
+	}
+}
+
+// RuntimeInputPaths returns the dotted paths of the fields of an entity YAML
+// still set to `<+input>`, in the order they appear. Elements of lists are
+// addressed by their identifier, or name for variables, skipping wrappers
+// such as `stage:` and `step:` the way Harness builds fully qualified names,
+// and by their index otherwise.
+func RuntimeInputPaths(text string) ([]string, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	collectRuntimeInputPaths(doc, "", &paths)
+	return paths, nil
+}
+
+func collectRuntimeInputPaths(node *yaml.Node, path string, paths *[]string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if strings.Contains(node.Value, runtimeInputExpression) {
+			*paths = append(*paths, path)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			item, key := sequenceItemKey(item)
+			if item.Kind == yaml.SequenceNode {
+				// A `parallel:` group does not add to the path.
+				collectRuntimeInputPaths(item, path, paths)
+				continue
+			}
+			if key == "" {
+				key = fmt.Sprint(i)
+			}
+			collectRuntimeInputPaths(item, joinYamlPath(path, key), paths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectRuntimeInputPaths(node.Content[i+1], joinYamlPath(path, node.Content[i].Value), paths)
+		}
+	case yaml.AliasNode:
+		collectRuntimeInputPaths(node.Alias, path, paths)
+	}
+}
+
+// sequenceItemKey unwraps a single key wrapper around a list element, such
+// as `step:` or `parallel:`, and returns the element along with the key
+// identifying it, if any.
+func sequenceItemKey(item *yaml.Node) (*yaml.Node, string) {
+	if item.Kind != yaml.MappingNode {
+		return item, ""
+	}
+	if len(item.Content) == 2 {
+		wrapper, inner := item.Content[0], item.Content[1]
+		if (inner.Kind == yaml.SequenceNode && wrapper.Value == "parallel") || (inner.Kind == yaml.MappingNode && mappingValue(inner, "identifier") != nil) {
+			item = inner
+		}
+	}
+	if item.Kind != yaml.MappingNode {
+		return item, ""
+	}
+	for _, key := range []string{"identifier", "name"} {
+		if value := mappingValue(item, key); value != nil && value.Kind == yaml.ScalarNode && value.Value != "" {
+			return item, value.Value
+		}
+	}
+	return item, ""
+}
+
+func joinYamlPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// YamlListItem decodes into out the element of the list at path, as in
+// `pipeline.notificationRules`, whose identifier is the given one, and
+// reports whether there is one.
+func YamlListItem(text string, path string, identifier string, out interface{}) (bool, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return false, err
+	}
+
+	list := yamlPathValue(doc, path)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return false, nil
+	}
+	i := yamlListItemIndex(list, identifier)
+	if i < 0 {
+		return false, nil
+	}
+	return true, list.Content[i].Decode(out)
+}
+
+// SetYamlListItem replaces the element of the list at path with the same
+// identifier as item, or appends item to the list, creating it if needed.
+// The rest of the document is left as it is.
+func SetYamlListItem(text string, path string, identifier string, item interface{}) (string, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return "", err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(item); err != nil {
+		return "", err
+	}
+
+	parent, key := doc, path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parent, key = yamlPathValue(doc, path[:i]), path[i+1:]
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("%q must be a mapping", path)
+	}
+	list := mappingValue(parent, key)
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, list)
+	}
+	if list.Kind != yaml.SequenceNode {
+		return "", fmt.Errorf("%q must be a list", path)
+	}
+
+	if i := yamlListItemIndex(list, identifier); i >= 0 {
+		list.Content[i] = &node
+	} else {
+		list.Content = append(list.Content, &node)
+	}
+	return encodeYaml(doc)
+}
+
+// RemoveYamlListItem removes the element of the list at path with the given
+// identifier, and reports whether there was one.
+func RemoveYamlListItem(text string, path string, identifier string) (string, bool, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return "", false, err
+	}
+
+	list := yamlPathValue(doc, path)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return text, false, nil
+	}
+	i := yamlListItemIndex(list, identifier)
+	if i < 0 {
+		return text, false, nil
+	}
+	list.Content = append(list.Content[:i], list.Content[i+1:]...)
+
+	out, err := encodeYaml(doc)
+	return out, true, err
+}
+
+// MergeYamlListItems adds to the list at path in text the elements of the
+// list at the same path in from whose identifiers it doesn't have, after its
+// own elements. Text is returned as it is when there are none.
+func MergeYamlListItems(text string, from string, path string) (string, error) {
+	source, err := parseYamlMapping(from)
+	if err != nil {
+		return "", err
+	}
+	items := yamlPathValue(source, path)
+	if items == nil || items.Kind != yaml.SequenceNode {
+		return text, nil
+	}
+
+	merged := text
+	for _, item := range items.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		identifier := mappingValue(item, "identifier")
+		if identifier == nil || identifier.Kind != yaml.ScalarNode || identifier.Value == "" {
+			continue
+		}
+		if found, err := YamlListItem(merged, path, identifier.Value, &yaml.Node{}); err != nil {
+			return "", err
+		} else if found {
+			continue
+		}
+		if merged, err = SetYamlListItem(merged, path, identifier.Value, item); err != nil {
+			return "", err
+		}
+	}
+	return merged, nil
+}
+
+// RemoveYamlPath returns text without the field at path, such as
+// `pipeline.notificationRules`, re-encoded the same way whether or not it
+// had one.
+func RemoveYamlPath(text string, path string) (string, error) {
+	doc, err := parseYamlMapping(text)
+	if err != nil {
+		return "", err
+	}
+
+	parent, key := doc, path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parent, key = yamlPathValue(doc, path[:i]), path[i+1:]
+	}
+	if parent != nil && parent.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == key {
+				parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+				break
+			}
+		}
+	}
+	return encodeYaml(doc)
+}
+
+func yamlPathValue(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		if node = mappingValue(node, key); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func yamlListItemIndex(list *yaml.Node, identifier string) int {
+	for i, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		if value := mappingValue(item, "identifier"); value != nil && value.Value == identifier {
+			return i
+		}
+	}
+	return -1
+}
+
+func encodeYaml(node *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,284 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testPipelineYaml = `pipeline:
+  name: test
+  identifier: test
+  variables:
+    - name: greeting
+      type: String
+      value: <+input>
+    - name: fixed
+      type: String
+      value: hello
+  stages:
+    - stage:
+        name: build
+        identifier: build
+        type: Custom
+        spec:
+          execution:
+            steps:
+              - step:
+                  name: echo
+                  identifier: echo
+                  type: ShellScript
+                  timeout: <+input>
+                  spec:
+                    shell: Bash
+    - stage:
+        name: deploy
+        identifier: deploy
+        type: Custom
+        spec:
+          execution:
+            steps: []
+`
+
+func TestValidateEntityYaml(t *testing.T) {
+	require.NoError(t, ValidateEntityYaml(testPipelineYaml, "pipeline"))
+
+	for name, text := range map[string]string{
+		"invalid YAML":       "pipeline: [",
+		"not a mapping":      "- pipeline",
+		"missing root":       "inputSet:\n  identifier: test\n",
+		"root not a mapping": "pipeline: test\n",
+		"missing identifier": "pipeline:\n  name: test\n",
+		"empty identifier":   "pipeline:\n  identifier: \"\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, ValidateEntityYaml(text, "pipeline"))
+		})
+	}
+}
+
+func TestRuntimeInputsTemplate(t *testing.T) {
+	template, err := RuntimeInputsTemplate(testPipelineYaml)
+	require.NoError(t, err)
+	require.Equal(t, `pipeline:
+  identifier: test
+  variables:
+    - name: greeting
+      type: String
+      value: <+input>
+  stages:
+    - stage:
+        identifier: build
+        type: Custom
+        spec:
+          execution:
+            steps:
+              - step:
+                  identifier: echo
+                  type: ShellScript
+                  timeout: <+input>
+`, template)
+
+	template, err = RuntimeInputsTemplate("pipeline:\n  identifier: test\n  name: test\n")
+	require.NoError(t, err)
+	require.Empty(t, template)
+
+	_, err = RuntimeInputsTemplate("pipeline: [")
+	require.Error(t, err)
+}
+
+func TestPruneToRuntimeInputs(t *testing.T) {
+	doc, err := parseYamlMapping(`
+identifier: test
+type: Custom
+aliased: &input <+input>.allowedValues(a,b)
+values:
+  - fixed
+  - *input
+nested:
+  fixed: value
+`)
+	require.NoError(t, err)
+
+	pruned, ok := pruneToRuntimeInputs(doc)
+	require.True(t, ok)
+	text, err := encodeYaml(pruned)
+	require.NoError(t, err)
+	require.Equal(t, `identifier: test
+type: Custom
+aliased: <+input>.allowedValues(a,b)
+values:
+  - <+input>.allowedValues(a,b)
+`, text)
+
+	doc, err = parseYamlMapping("identifier: test\nnested:\n  fixed: value\n")
+	require.NoError(t, err)
+	_, ok = pruneToRuntimeInputs(doc)
+	require.False(t, ok)
+}
+
+func TestYamlEqual(t *testing.T) {
+	require.True(t, YamlEqual("a: 1\nb: [x, y]\n", "b:\n  - x\n  - \"y\"\na: 1\n"))
+	require.True(t, YamlEqual("pipeline: [", "pipeline: ["))
+	require.False(t, YamlEqual("a: 1\n", "a: \"1\"\n"))
+	require.False(t, YamlEqual("b: [x, y]\n", "b: [y, x]\n"))
+	require.False(t, YamlEqual("a: 1\n", "a: ["))
+}
+
+func TestRuntimeInputPaths(t *testing.T) {
+	for name, test := range map[string]struct {
+		text  string
+		paths []string
+	}{
+		"none": {
+			text:  "pipeline:\n  identifier: test\n",
+			paths: nil,
+		},
+		"pipeline": {
+			text:  testPipelineYaml,
+			paths: []string{"pipeline.variables.greeting.value", "pipeline.stages.build.spec.execution.steps.echo.timeout"},
+		},
+		"parallel stages": {
+			text: `pipeline:
+  identifier: test
+  stages:
+    - parallel:
+        - stage:
+            identifier: one
+            spec:
+              timeout: <+input>
+        - stage:
+            identifier: two
+            spec:
+              timeout: <+input>.default(10m)
+`,
+			paths: []string{"pipeline.stages.one.spec.timeout", "pipeline.stages.two.spec.timeout"},
+		},
+		"unidentified items": {
+			text: `inputSet:
+  identifier: test
+  values:
+    - fixed
+    - <+input>
+  items:
+    - key: <+input>
+    - values: [<+input>]
+`,
+			paths: []string{"inputSet.values.1", "inputSet.items.0.key", "inputSet.items.1.values.0"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			paths, err := RuntimeInputPaths(test.text)
+			require.NoError(t, err)
+			require.Equal(t, test.paths, paths)
+		})
+	}
+
+	_, err := RuntimeInputPaths("- pipeline")
+	require.Error(t, err)
+}
+
+func TestSequenceItemKey(t *testing.T) {
+	for name, test := range map[string]struct {
+		text string
+		key  string
+		kind yaml.Kind
+	}{
+		"scalar":            {text: "value", key: "", kind: yaml.ScalarNode},
+		"identifier":        {text: "identifier: echo\nname: Echo\n", key: "echo", kind: yaml.MappingNode},
+		"name":              {text: "name: greeting\ntype: String\n", key: "greeting", kind: yaml.MappingNode},
+		"empty identifier":  {text: "identifier: \"\"\nname: greeting\n", key: "greeting", kind: yaml.MappingNode},
+		"wrapper":           {text: "step:\n  identifier: echo\n", key: "echo", kind: yaml.MappingNode},
+		"parallel wrapper":  {text: "parallel:\n  - stage:\n      identifier: one\n", key: "", kind: yaml.SequenceNode},
+		"wrapped variable":  {text: "variable:\n  name: greeting\n", key: "", kind: yaml.MappingNode},
+		"several keys":      {text: "key: a\nvalue: b\n", key: "", kind: yaml.MappingNode},
+		"identifier wins":   {text: "name: Echo\nidentifier: echo\n", key: "echo", kind: yaml.MappingNode},
+		"non scalar name":   {text: "name: [a]\ntype: x\n", key: "", kind: yaml.MappingNode},
+		"single list key":   {text: "values: [a]\n", key: "", kind: yaml.MappingNode},
+		"single other key":  {text: "key: <+input>\n", key: "", kind: yaml.MappingNode},
+		"wrapper of scalar": {text: "step: echo\n", key: "", kind: yaml.MappingNode},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(test.text), &doc))
+
+			item, key := sequenceItemKey(doc.Content[0])
+			require.Equal(t, test.key, key)
+			require.Equal(t, test.kind, item.Kind)
+		})
+	}
+}
+
+func TestMergeYamlListItems(t *testing.T) {
+	const current = `pipeline:
+  identifier: test
+  notificationRules:
+    - name: failures
+      identifier: failures
+      enabled: true
+    - name: external
+      identifier: external
+      enabled: true
+    - not an item
+`
+
+	merged, err := MergeYamlListItems("pipeline:\n  identifier: test\n", current, "pipeline.notificationRules")
+	require.NoError(t, err)
+	require.Equal(t, `pipeline:
+  identifier: test
+  notificationRules:
+    - name: failures
+      identifier: failures
+      enabled: true
+    - name: external
+      identifier: external
+      enabled: true
+`, merged)
+
+	// Configured items win over those with the same identifier.
+	merged, err = MergeYamlListItems(`pipeline:
+  identifier: test
+  notificationRules:
+    - name: failures
+      identifier: failures
+      enabled: false
+`, current, "pipeline.notificationRules")
+	require.NoError(t, err)
+	require.Equal(t, `pipeline:
+  identifier: test
+  notificationRules:
+    - name: failures
+      identifier: failures
+      enabled: false
+    - name: external
+      identifier: external
+      enabled: true
+`, merged)
+
+	text := "pipeline:\n    identifier: test\n"
+	merged, err = MergeYamlListItems(text, "pipeline:\n  identifier: test\n", "pipeline.notificationRules")
+	require.NoError(t, err)
+	require.Equal(t, text, merged)
+
+	_, err = MergeYamlListItems(text, "pipeline: [", "pipeline.notificationRules")
+	require.Error(t, err)
+}
+
+func TestRemoveYamlPath(t *testing.T) {
+	text, err := RemoveYamlPath(`pipeline:
+    identifier: test
+    notificationRules:
+        - identifier: failures
+    stages: []
+`, "pipeline.notificationRules")
+	require.NoError(t, err)
+	require.Equal(t, "pipeline:\n  identifier: test\n  stages: []\n", text)
+
+	text, err = RemoveYamlPath("pipeline:\n    identifier: test\n    stages: []\n", "pipeline.notificationRules")
+	require.NoError(t, err)
+	require.Equal(t, "pipeline:\n  identifier: test\n  stages: []\n", text)
+
+	_, err = RemoveYamlPath("- pipeline", "pipeline.notificationRules")
+	require.Error(t, err)
+}
@@ -126,6 +126,7 @@ func Provider(version string) func() *schema.Provider {
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"harness_platform_template":                        pl_template.DataSourceTemplate(),
+				"harness_platform_template_references":             pl_template.DataSourceTemplateReferences(),
 				"harness_platform_connector_azure_key_vault":       connector.DataSourceConnectorAzureKeyVault(),
 				"harness_platform_connector_gcp_cloud_cost":        connector.DataSourceConnectorGCPCloudCost(),
 				"harness_platform_connector_kubernetes_cloud_cost": connector.DatasourceConnectorKubernetesCloudCost(),
@@ -166,19 +167,29 @@ func Provider(version string) func() *schema.Provider {
 				"harness_platform_environment_clusters_mapping":    pl_environment_clusters_mapping.DataSourceEnvironmentClustersMapping(),
 				"harness_platform_environment_service_overrides":   pl_environment_service_overrides.DataSourceEnvironmentServiceOverrides(),
 				"harness_platform_service_overrides_v2":            pl_service_overrides_v2.DataSourceServiceOverrides(),
+				"harness_platform_feature_flag":                    feature_flag.DataSourceFeatureFlag(),
+				"harness_platform_feature_flags":                   feature_flag.DataSourceFeatureFlags(),
+				"harness_platform_feature_flag_target":             feature_flag.DataSourceFeatureFlagTarget(),
+				"harness_platform_feature_flag_target_group":       feature_flag.DataSourceFeatureFlagTargetGroup(),
+				"harness_platform_ff_api_key":                      ff_api_key.DataSourceFFApiKey(),
 				"harness_platform_gitops_agent":                    gitops_agent.DataSourceGitopsAgent(),
 				"harness_platform_gitops_agent_deploy_yaml":        agent_yaml.DataSourceGitopsAgentDeployYaml(),
+				"harness_platform_gitops_application_resources":    gitops_applications.DataSourceGitopsApplicationResources(),
 				"harness_platform_gitops_applications":             gitops_applications.DataSourceGitopsApplications(),
 				"harness_platform_gitops_cluster":                  gitops_cluster.DataSourceGitopsCluster(),
 				"harness_platform_gitops_gnupg":                    gitops_gnupg.DataSourceGitopsGnupg(),
 				"harness_platform_gitops_repository":               gitops_repository.DataSourceGitopsRepository(),
+				"harness_platform_gitops_repositories":             gitops_repository.DataSourceGitopsRepositoryList(),
 				"harness_platform_gitops_repo_cert":                gitops_repo_cert.DataSourceGitOpsRepoCert(),
 				"harness_platform_gitops_repo_cred":                gitops_repo_cred.DataSourceGitOpsRepoCred(),
 				"harness_platform_infrastructure":                  pl_infrastructure.DataSourceInfrastructure(),
 				"harness_platform_input_set":                       input_set.DataSourceInputSet(),
+				"harness_platform_input_set_merge":                 input_set.DataSourceInputSetMerge(),
 				"harness_platform_monitored_service":               monitored_service.DataSourceMonitoredService(),
 				"harness_platform_organization":                    organization.DataSourceOrganization(),
 				"harness_platform_pipeline":                        pipeline.DataSourcePipeline(),
+				"harness_platform_pipelines":                       pipeline.DataSourcePipelines(),
+				"harness_platform_pipeline_execution":              pipeline.DataSourcePipelineExecution(),
 				"harness_platform_permissions":                     pl_permissions.DataSourcePermissions(),
 				"harness_platform_project":                         project.DataSourceProject(),
 				"harness_platform_service":                         pl_service.DataSourceService(),
@@ -224,6 +235,7 @@ func Provider(version string) func() *schema.Provider {
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"harness_platform_template":                        pl_template.ResourceTemplate(),
+				"harness_platform_template_versions":               pl_template.ResourceTemplateVersions(),
 				"harness_platform_connector_azure_key_vault":       connector.ResourceConnectorAzureKeyVault(),
 				"harness_platform_connector_gcp_cloud_cost":        connector.ResourceConnectorGCPCloudCost(),
 				"harness_platform_connector_kubernetes_cloud_cost": connector.ResourceConnectorKubernetesCloudCost(),
@@ -264,19 +276,27 @@ func Provider(version string) func() *schema.Provider {
 				"harness_platform_environment_service_overrides":   pl_environment_service_overrides.ResourceEnvironmentServiceOverrides(),
 				"harness_platform_service_overrides_v2":            pl_service_overrides_v2.ResourceServiceOverrides(),
 				"harness_platform_feature_flag":                    feature_flag.ResourceFeatureFlag(),
+				"harness_platform_feature_flag_environment":        feature_flag.ResourceFeatureFlagEnvironment(),
+				"harness_platform_feature_flag_target":             feature_flag.ResourceFeatureFlagTarget(),
+				"harness_platform_feature_flag_target_group":       feature_flag.ResourceFeatureFlagTargetGroup(),
 				"harness_platform_ff_api_key":                      ff_api_key.ResourceFFApiKey(),
 				"harness_platform_gitops_agent":                    gitops_agent.ResourceGitopsAgent(),
 				"harness_platform_gitops_applications":             gitops_applications.ResourceGitopsApplication(),
 				"harness_platform_gitops_cluster":                  gitops_cluster.ResourceGitopsCluster(),
 				"harness_platform_gitops_gnupg":                    gitops_gnupg.ResourceGitopsGnupg(),
 				"harness_platform_gitops_repository":               gitops_repository.ResourceGitopsRepositories(),
+				"harness_platform_gitops_repositories":             gitops_repository.ResourceGitopsRepositoryBatch(),
 				"harness_platform_gitops_repo_cert":                gitops_repo_cert.ResourceGitopsRepoCerts(),
 				"harness_platform_gitops_repo_cred":                gitops_repo_cred.ResourceGitopsRepoCred(),
+				"harness_platform_gitops_sync_operation":           gitops_applications.ResourceGitopsSyncOperation(),
 				"harness_platform_infrastructure":                  pl_infrastructure.ResourceInfrastructure(),
 				"harness_platform_input_set":                       input_set.ResourceInputSet(),
+				"harness_platform_overlay_input_set":               input_set.ResourceOverlayInputSet(),
 				"harness_platform_monitored_service":               monitored_service.ResourceMonitoredService(),
 				"harness_platform_organization":                    organization.ResourceOrganization(),
 				"harness_platform_pipeline":                        pipeline.ResourcePipeline(),
+				"harness_platform_pipeline_notification":           pipeline.ResourcePipelineNotification(),
+				"harness_platform_pipeline_execution":              pipeline.ResourcePipelineExecution(),
 				"harness_platform_project":                         project.ResourceProject(),
 				"harness_platform_service":                         pl_service.ResourceService(),
 				"harness_platform_user":                            pl_user.ResourceUser(),
@@ -0,0 +1,199 @@
+package feature_flag
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatureFlag() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving a Feature Flag.",
+
+		ReadContext: dataSourceFeatureFlagRead,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Feature Flag",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier. When set, `state` holds the on/off state of the flag in this environment.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name": {
+				Description: "Name of the Feature Flag",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "Description of the Feature Flag",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kind": {
+				Description: "The type of data the flag represents",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owner": {
+				Description: "The owners of the flag",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"archived": {
+				Description: "Whether or not the flag is archived",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"permanent": {
+				Description: "Whether or not the flag is permanent",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"default_on_variation": {
+				Description: "The variation served when the flag is on",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"default_off_variation": {
+				Description: "The variation served when the flag is off",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The usage status of the flag, such as `active`, `inactive`, `never-requested` or `potentially-stale`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "The state of the flag in the environment given by `env_id`, either `on` or `off`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"tags": {
+				Description: "Tags of the flag, as `name:value` strings",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"variation": {
+				Description: "The options available for the flag",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "The identifier of the variation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the variation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The user friendly name of the variation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"value": {
+							Description: "The value of the variation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourceFeatureFlagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildFFQueryParameters(d)
+	opts := &nextgen.FeatureFlagsApiGetFeatureFlagOpts{
+		EnvironmentIdentifier: optional.EmptyString(),
+	}
+	if envId, ok := d.GetOk("env_id"); ok {
+		opts.EnvironmentIdentifier = optional.NewString(envId.(string))
+	}
+
+	resp, httpResp, err := c.FeatureFlagsApi.GetFeatureFlag(ctx, qp.Identifier, c.AccountId, qp.OrganizationId, qp.ProjectId, opts)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	d.SetId(resp.Identifier)
+	d.Set("identifier", resp.Identifier)
+	d.Set("name", resp.Name)
+	d.Set("description", resp.Description)
+	d.Set("kind", resp.Kind)
+	d.Set("owner", resp.Owner)
+	d.Set("archived", resp.Archived)
+	d.Set("permanent", resp.Permanent)
+	d.Set("default_on_variation", resp.DefaultOnVariation)
+	d.Set("default_off_variation", resp.DefaultOffVariation)
+	d.Set("status", featureFlagStatus(&resp))
+	d.Set("state", featureFlagState(&resp))
+	d.Set("tags", flattenFeatureFlagTags(resp.Tags))
+	d.Set("variation", expandVariations(resp.Variations))
+
+	return nil
+}
+
+func featureFlagStatus(flag *nextgen.Feature) string {
+	if flag.Status == nil {
+		return ""
+	}
+
+	return flag.Status.Status
+}
+
+func featureFlagState(flag *nextgen.Feature) string {
+	if flag.EnvProperties == nil || flag.EnvProperties.State == nil {
+		return ""
+	}
+
+	return string(*flag.EnvProperties.State)
+}
+
+func flattenFeatureFlagTags(tags []nextgen.Tag) []string {
+	var result []string
+	for _, tag := range tags {
+		if tag.Value == "" {
+			result = append(result, tag.Name)
+		} else {
+			result = append(result, tag.Name+":"+tag.Value)
+		}
+	}
+
+	return result
+}
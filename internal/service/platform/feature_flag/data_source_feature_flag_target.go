@@ -0,0 +1,86 @@
+package feature_flag
+
+import (
+	"context"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatureFlagTarget() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving a Feature Flag Target.",
+
+		ReadContext: dataSourceFeatureFlagTargetRead,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Target",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Name of the Target",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes": {
+				Description: "Attributes of the Target",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"target_groups": {
+				Description: "Identifiers of the target groups the Target belongs to",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourceFeatureFlagTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetQueryParameters(d)
+
+	resp, httpResp, err := c.TargetsApi.GetTarget(ctx, qp.Identifier, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTarget(d, &resp, qp)
+
+	var groups []string
+	for _, segment := range resp.Segments {
+		groups = append(groups, segment.Identifier)
+	}
+	d.Set("target_groups", groups)
+
+	return nil
+}
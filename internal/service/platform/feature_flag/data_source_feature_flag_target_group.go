@@ -0,0 +1,112 @@
+package feature_flag
+
+import (
+	"context"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatureFlagTargetGroup() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving a Feature Flag Target Group.",
+
+		ReadContext: dataSourceFeatureFlagTargetGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Target Group",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Name of the Target Group",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"included": {
+				Description: "Identifiers of the targets always included in the group",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"excluded": {
+				Description: "Identifiers of the targets always excluded from the group",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rules": {
+				Description: "Attribute rules; targets matching any of them are included in the group",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clause_id": {
+							Description: "The unique identifier of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"attribute": {
+							Description: "The target attribute to evaluate",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"op": {
+							Description: "The operator used to compare the attribute",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"values": {
+							Description: "The values compared against the attribute",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourceFeatureFlagTargetGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetGroupQueryParameters(d)
+
+	resp, httpResp, err := c.TargetGroupsApi.GetSegment(ctx, c.AccountId, qp.OrganizationId, qp.Identifier, qp.ProjectId, qp.EnvironmentId)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTargetGroup(d, &resp, qp)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFeatureFlagTargetGroup(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "data.harness_platform_feature_flag_target_group.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFeatureFlagTargetGroup(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "included.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.attribute", "email"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFeatureFlagTargetGroup(id string, name string) string {
+	return fmt.Sprintf(`
+		%[1]s
+
+		data "harness_platform_feature_flag_target_group" "test" {
+			identifier = harness_platform_feature_flag_target_group.test.id
+			org_id = harness_platform_feature_flag_target_group.test.org_id
+			project_id = harness_platform_feature_flag_target_group.test.project_id
+			env_id = harness_platform_feature_flag_target_group.test.env_id
+		}
+`, testAccResourceFeatureFlagTargetGroup(id, name, "@example.com"))
+}
@@ -0,0 +1,45 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFeatureFlagTarget(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "data.harness_platform_feature_flag_target.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFeatureFlagTarget(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "attributes.plan", "beta"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFeatureFlagTarget(id string, name string) string {
+	return fmt.Sprintf(`
+		%[1]s
+
+		data "harness_platform_feature_flag_target" "test" {
+			identifier = harness_platform_feature_flag_target.test.id
+			org_id = harness_platform_feature_flag_target.test.org_id
+			project_id = harness_platform_feature_flag_target.test.project_id
+			env_id = harness_platform_feature_flag_target.test.env_id
+		}
+`, testAccResourceFeatureFlagTarget(id, name, "beta"))
+}
@@ -0,0 +1,47 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFeatureFlag(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "data.harness_platform_feature_flag.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFeatureFlag(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "kind", "boolean"),
+					resource.TestCheckResourceAttr(resourceName, "permanent", "false"),
+					resource.TestCheckResourceAttr(resourceName, "default_on_variation", "Enabled"),
+					resource.TestCheckResourceAttr(resourceName, "variation.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFeatureFlag(id string, name string) string {
+	return fmt.Sprintf(`
+		%[1]s
+
+		data "harness_platform_feature_flag" "test" {
+			identifier = harness_platform_feature_flag.test.identifier
+			org_id = harness_platform_feature_flag.test.org_id
+			project_id = harness_platform_feature_flag.test.project_id
+		}
+`, testAccResourceFeatureFlag(id, name, "Enabled", "Enabled"))
+}
@@ -0,0 +1,230 @@
+package feature_flag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// staleStatus is the usage status Harness reports for flags that have not
+// been evaluated recently and are candidates for removal.
+const staleStatus = "potentially-stale"
+
+const featureFlagsPageSize = 100
+
+func DataSourceFeatureFlags() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for listing the Feature Flags of a project.",
+
+		ReadContext: dataSourceFeatureFlagsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier. When set, each flag reports its on/off `state` in this environment.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name": {
+				Description: "Only return flags whose name contains this value",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Only return flags carrying all of these tags. Tags are given as `name` or `name:value`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"stale": {
+				Description: "When true, only return flags whose status is `potentially-stale`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"permanent": {
+				Description: "When set, only return flags whose permanent setting matches this value",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"flags": {
+				Description: "The matching Feature Flags",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the Feature Flag",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the Feature Flag",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "The type of data the flag represents",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"owner": {
+							Description: "The owners of the flag",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"archived": {
+							Description: "Whether or not the flag is archived",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"permanent": {
+							Description: "Whether or not the flag is permanent",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The usage status of the flag",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "The state of the flag in the environment given by `env_id`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "Tags of the flag, as `name:value` strings",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created_at": {
+							Description: "Creation time of the flag in milliseconds",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"modified_at": {
+							Description: "Last modification time of the flag in milliseconds",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourceFeatureFlagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+
+	opts := &nextgen.FeatureFlagsApiGetAllFeaturesOpts{
+		PageSize: optional.NewInt32(featureFlagsPageSize),
+	}
+	if envId, ok := d.GetOk("env_id"); ok {
+		opts.EnvironmentIdentifier = optional.NewString(envId.(string))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		opts.Name = optional.NewString(name.(string))
+	}
+
+	var features []nextgen.Feature
+	for page := int32(0); ; page++ {
+		opts.PageNumber = optional.NewInt32(page)
+		resp, httpResp, err := c.FeatureFlagsApi.GetAllFeatures(ctx, c.AccountId, orgId, projectId, opts)
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+
+		features = append(features, resp.Features...)
+		if page+1 >= resp.PageCount || len(resp.Features) == 0 {
+			break
+		}
+	}
+
+	tags := helpers.ExpandField(d.Get("tags").(*schema.Set).List())
+	staleOnly := d.Get("stale").(bool)
+	// permanent is a tri-state filter, so an unset value must not be read as false.
+	permanent := d.GetRawConfig().GetAttr("permanent")
+
+	var flags []interface{}
+	for i := range features {
+		flag := &features[i]
+		if staleOnly && featureFlagStatus(flag) != staleStatus {
+			continue
+		}
+		if !permanent.IsNull() && flag.Permanent != permanent.True() {
+			continue
+		}
+		flagTags := flattenFeatureFlagTags(flag.Tags)
+		if !hasAllTags(flag.Tags, tags) {
+			continue
+		}
+
+		flags = append(flags, map[string]interface{}{
+			"identifier":  flag.Identifier,
+			"name":        flag.Name,
+			"kind":        flag.Kind,
+			"owner":       flag.Owner,
+			"archived":    flag.Archived,
+			"permanent":   flag.Permanent,
+			"status":      featureFlagStatus(flag),
+			"state":       featureFlagState(flag),
+			"tags":        flagTags,
+			"created_at":  int(flag.CreatedAt),
+			"modified_at": int(flag.ModifiedAt),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", orgId, projectId))
+	d.Set("flags", flags)
+
+	return nil
+}
+
+// hasAllTags reports whether every wanted tag, given as `name` or
+// `name:value`, is present on the flag.
+func hasAllTags(flagTags []nextgen.Tag, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, tag := range flagTags {
+			if w == tag.Name || w == tag.Name+":"+tag.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,157 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFeatureFlags(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFeatureFlags(id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.all", "flags.#", "2"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.all", "flags.0.state", ""),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.named", "flags.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.named", "flags.0.identifier", id+"_temporary"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.permanent", "flags.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.permanent", "flags.0.identifier", id+"_permanent"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.permanent", "flags.0.permanent", "true"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.temporary", "flags.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.temporary", "flags.0.identifier", id+"_temporary"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.temporary", "flags.0.permanent", "false"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.tagged", "flags.#", "0"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.stale", "flags.#", "0"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.in_env", "flags.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_feature_flags.in_env", "flags.0.state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFeatureFlags(id string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_environment" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			type = "PreProduction"
+		}
+
+		resource "harness_platform_feature_flag" "test" {
+			for_each = { permanent = true, temporary = false }
+
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+
+			kind = "boolean"
+			name = "%[1]s_${each.key}"
+			identifier = "%[1]s_${each.key}"
+			permanent = each.value
+
+			default_on_variation = "Enabled"
+			default_off_variation = "Disabled"
+
+			variation {
+				identifier = "Enabled"
+				name = "Enabled"
+				description = "The feature is enabled"
+				value = "true"
+			}
+
+			variation {
+				identifier = "Disabled"
+				name = "Disabled"
+				description = "The feature is disabled"
+				value = "false"
+			}
+		}
+
+		resource "harness_platform_feature_flag_environment" "test" {
+			identifier = harness_platform_feature_flag.test["temporary"].identifier
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+
+			state = "on"
+
+			default_serve {
+				variation = "Enabled"
+			}
+		}
+
+		data "harness_platform_feature_flags" "all" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "named" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "temporary"
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "permanent" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			permanent = true
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "temporary" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			permanent = false
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "tagged" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			tags = ["team:none"]
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "stale" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			stale = true
+			depends_on = [harness_platform_feature_flag.test]
+		}
+
+		data "harness_platform_feature_flags" "in_env" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+			name = "temporary"
+			depends_on = [harness_platform_feature_flag_environment.test]
+		}
+`, id)
+}
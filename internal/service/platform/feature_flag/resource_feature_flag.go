@@ -2,7 +2,7 @@ package feature_flag
 
 import (
 	"context"
-	"io/ioutil"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -12,17 +12,23 @@ import (
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// readAfterWriteTimeout bounds how long we wait for a written flag to become readable.
+const readAfterWriteTimeout = 1 * time.Minute
+
 func ResourceFeatureFlag() *schema.Resource {
 	resource := &schema.Resource{
 		Description: "Resource for managing Feature Flags.",
 
 		ReadContext:   resourceFeatureFlagRead,
+		UpdateContext: resourceFeatureFlagUpdate,
 		DeleteContext: resourceFeatureFlagDelete,
 		CreateContext: resourceFeatureFlagCreate,
 		Importer:      helpers.ProjectResourceImporter,
+		CustomizeDiff: resourceFeatureFlagCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"identifier": {
@@ -35,7 +41,6 @@ func ResourceFeatureFlag() *schema.Resource {
 				Description: "Name of the Feature Flag",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"org_id": {
 				Description: "Organization Identifier",
@@ -49,28 +54,29 @@ func ResourceFeatureFlag() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"description": {
+				Description: "Description of the Feature Flag",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 			"archived": {
-				Description: "Whether or not the flag is archived",
+				Description: "Whether or not the flag is archived. An archived flag cannot be restored through the API, so this cannot be set back to false.",
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 			},
 			"default_off_variation": {
 				Description: "Which of the variations to use when the flag is toggled to off state",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"default_on_variation": {
 				Description: "Which of the variations to use when the flag is toggled to on state",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"git_details": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -92,19 +98,16 @@ func ResourceFeatureFlag() *schema.Resource {
 				Description: "The owner of the flag",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 			},
 			"permanent": {
 				Description: "Whether or not the flag is permanent. If it is, it will never be flagged as stale",
 				Type:        schema.TypeBool,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"variation": {
 				Description: "The options available for your flag",
 				Type:        schema.TypeList,
 				Required:    true,
-				ForceNew:    true,
 				MinItems:    2,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -137,6 +140,19 @@ func ResourceFeatureFlag() *schema.Resource {
 	return resource
 }
 
+// resourceFeatureFlagCustomizeDiff rejects restoring an archived flag, which
+// the Feature Flags API does with a dedicated restore endpoint this client
+// does not expose.
+func resourceFeatureFlagCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("archived") {
+		return nil
+	}
+	if o, _ := d.GetChange("archived"); o.(bool) {
+		return fmt.Errorf("feature flag %s is archived and cannot be restored by the provider, restore it in Harness first", d.Id())
+	}
+	return nil
+}
+
 type FFQueryParameters struct {
 	Identifier     string
 	OrganizationId string
@@ -192,26 +208,50 @@ func resourceFeatureFlagCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	qp := buildFFQueryParameters(d)
 	opts := buildFFCreateOpts(d)
-	readOpts := buildFFReadOpts(d)
 
-	var err error
-	var resp nextgen.Feature
-	var httpResp *http.Response
+	httpResp, err := c.FeatureFlagsApi.CreateFeatureFlag(ctx, c.AccountId, qp.OrganizationId, opts)
 
-	httpResp, err = c.FeatureFlagsApi.CreateFeatureFlag(ctx, c.AccountId, qp.OrganizationId, opts)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	resp, httpResp, err := waitForFeatureFlag(ctx, c, id, qp, buildFFReadOpts(d))
 
 	if err != nil {
 		return helpers.HandleApiError(err, d, httpResp)
 	}
 
-	time.Sleep(1 * time.Second)
+	readFeatureFlag(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	qp := buildFFQueryParameters(d)
+
+	if opts := buildFFPatchOpts(d); opts != nil {
+		_, httpResp, err := c.FeatureFlagsApi.PatchFeature(ctx, c.AccountId, qp.OrganizationId, qp.ProjectId, id, opts)
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+	}
+
+	if d.HasChange("archived") && d.Get("archived").(bool) {
+		httpResp, err := archiveFeatureFlag(ctx, c, d, id, qp)
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+	}
 
-	resp, httpResp, err = c.FeatureFlagsApi.GetFeatureFlag(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, readOpts)
+	resp, httpResp, err := waitForFeatureFlag(ctx, c, id, qp, buildFFReadOpts(d))
 
 	if err != nil {
-		body, _ := ioutil.ReadAll(httpResp.Body)
-		return diag.Errorf("readstatus: %s, \nBody:%s", httpResp.Status, body)
-		//return helpers.HandleReadApiError(err, d, httpResp)
+		return helpers.HandleApiError(err, d, httpResp)
 	}
 
 	readFeatureFlag(d, &resp, qp)
@@ -219,6 +259,49 @@ func resourceFeatureFlagCreate(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// archiveFeatureFlag archives a flag. The Feature Flags API has no patch
+// instruction for it: deleting a flag without forceDelete archives it.
+func archiveFeatureFlag(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, id string, qp *FFQueryParameters) (*http.Response, error) {
+	opts := &nextgen.FeatureFlagsApiDeleteFeatureFlagOpts{CommitMsg: optional.EmptyString()}
+	if gitDetails, ok := d.GetOk("git_details"); ok {
+		opts.CommitMsg = optional.NewString(buildGitDetails(gitDetails.(*schema.Set)).CommitMsg)
+	}
+	return c.FeatureFlagsApi.DeleteFeatureFlag(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, opts)
+}
+
+// waitForFeatureFlag polls the flag until it can be read back.
+func waitForFeatureFlag(ctx context.Context, c *nextgen.APIClient, id string, qp *FFQueryParameters, opts *nextgen.FeatureFlagsApiGetFeatureFlagOpts) (nextgen.Feature, *http.Response, error) {
+	var resp nextgen.Feature
+	httpResp, err := waitForReadAfterWrite(ctx, func() (*http.Response, error) {
+		var httpResp *http.Response
+		var err error
+		resp, httpResp, err = c.FeatureFlagsApi.GetFeatureFlag(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, opts)
+		return httpResp, err
+	})
+	return resp, httpResp, err
+}
+
+// waitForReadAfterWrite retries read for as long as it returns a 404, since
+// writes to the Feature Flags service are not immediately visible to
+// subsequent reads.
+func waitForReadAfterWrite(ctx context.Context, read func() (*http.Response, error)) (*http.Response, error) {
+	var httpResp *http.Response
+
+	err := retry.RetryContext(ctx, readAfterWriteTimeout, func() *retry.RetryError {
+		var err error
+		httpResp, err = read()
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	return httpResp, err
+}
+
 func resourceFeatureFlagDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
 
@@ -245,11 +328,11 @@ func readFeatureFlag(d *schema.ResourceData, flag *nextgen.Feature, qp *FFQueryP
 	d.Set("default_off_variation", flag.DefaultOffVariation)
 	d.Set("description", flag.Description)
 	d.Set("kind", flag.Kind)
+	d.Set("archived", flag.Archived)
 	d.Set("permanent", flag.Permanent)
 	d.Set("owner", strings.Join(flag.Owner, ","))
 	d.Set("org_id", qp.OrganizationId)
 	d.Set("variation", expandVariations(flag.Variations))
-
 }
 
 func expandVariations(variations []nextgen.Variation) []interface{} {
@@ -296,8 +379,134 @@ func buildFFCreateOpts(d *schema.ResourceData) *nextgen.FeatureFlagsApiCreateFea
 		opts.Archived = archived.(bool)
 	}
 
+	opts.Variations = buildVariations(d.Get("variation").([]interface{}))
+
+	if gitDetails, ok := d.GetOk("git_details"); ok {
+		opts.GitDetails = buildGitDetails(gitDetails.(*schema.Set))
+	}
+
+	return &nextgen.FeatureFlagsApiCreateFeatureFlagOpts{
+		Body: optional.NewInterface(opts),
+	}
+}
+
+// buildFFPatchOpts translates the planned changes into patch instructions, as
+// listed for patchFeature in the Feature Flags API reference. New variations
+// are added before the defaults are moved onto them, and removed variations
+// are only deleted once no default points at them anymore. Archiving is not a
+// patch instruction, see archiveFeatureFlag.
+func buildFFPatchOpts(d *schema.ResourceData) *nextgen.FeatureFlagsApiPatchFeatureOpts {
+	var instructions []nextgen.PatchInstructionInner
+
+	if d.HasChange("name") {
+		instructions = append(instructions, ffInstruction("updateName", map[string]interface{}{
+			"name": d.Get("name").(string),
+		}))
+	}
+
+	if d.HasChange("description") {
+		instructions = append(instructions, ffInstruction("updateDescription", map[string]interface{}{
+			"description": d.Get("description").(string),
+		}))
+	}
+
+	if d.HasChange("permanent") {
+		instructions = append(instructions, ffInstruction("updatePermanent", map[string]interface{}{
+			"permanent": d.Get("permanent").(bool),
+		}))
+	}
+
+	if d.HasChange("owner") {
+		var owners []string
+		if owner := d.Get("owner").(string); owner != "" {
+			owners = strings.Split(owner, ",")
+		}
+		instructions = append(instructions, ffInstruction("updateOwner", map[string]interface{}{
+			"owner": owners,
+		}))
+	}
+
+	var removed []string
+	if d.HasChange("variation") {
+		o, n := d.GetChange("variation")
+		oldVariations := buildVariations(o.([]interface{}))
+		newVariations := buildVariations(n.([]interface{}))
+
+		existing := map[string]nextgen.Variation{}
+		for _, v := range oldVariations {
+			existing[v.Identifier] = v
+		}
+
+		for _, v := range newVariations {
+			old, ok := existing[v.Identifier]
+			delete(existing, v.Identifier)
+			if ok && old == v {
+				continue
+			}
+			kind := "addVariation"
+			if ok {
+				kind = "updateVariation"
+			}
+			instructions = append(instructions, ffInstruction(kind, map[string]interface{}{
+				"identifier":  v.Identifier,
+				"name":        v.Name,
+				"description": v.Description,
+				"value":       v.Value,
+			}))
+		}
+
+		for _, v := range oldVariations {
+			if _, ok := existing[v.Identifier]; ok {
+				removed = append(removed, v.Identifier)
+			}
+		}
+	}
+
+	if d.HasChange("default_on_variation") {
+		instructions = append(instructions, ffInstruction("setDefaultOnVariation", map[string]interface{}{
+			"variation": d.Get("default_on_variation").(string),
+		}))
+	}
+
+	if d.HasChange("default_off_variation") {
+		instructions = append(instructions, ffInstruction("setDefaultOffVariation", map[string]interface{}{
+			"variation": d.Get("default_off_variation").(string),
+		}))
+	}
+
+	for _, identifier := range removed {
+		instructions = append(instructions, ffInstruction("deleteVariation", map[string]interface{}{
+			"identifier": identifier,
+		}))
+	}
+
+	if len(instructions) == 0 {
+		return nil
+	}
+
+	body := nextgen.GitSyncPatchOperation{
+		Instructions: &instructions,
+	}
+
+	if gitDetails, ok := d.GetOk("git_details"); ok {
+		details := buildGitDetails(gitDetails.(*schema.Set))
+		body.GitDetails = &details
+	}
+
+	return &nextgen.FeatureFlagsApiPatchFeatureOpts{
+		Body: optional.NewInterface(body),
+	}
+}
+
+func ffInstruction(kind string, parameters interface{}) nextgen.PatchInstructionInner {
+	return nextgen.PatchInstructionInner{
+		Kind:       kind,
+		Parameters: &parameters,
+	}
+}
+
+func buildVariations(variationsData []interface{}) []nextgen.Variation {
 	var variations []nextgen.Variation
-	variationsData := d.Get("variation").([]interface{})
 	for _, variationData := range variationsData {
 		vMap := variationData.(map[string]interface{})
 		variation := nextgen.Variation{
@@ -308,12 +517,17 @@ func buildFFCreateOpts(d *schema.ResourceData) *nextgen.FeatureFlagsApiCreateFea
 		}
 		variations = append(variations, variation)
 	}
-	opts.Variations = variations
 
-	return &nextgen.FeatureFlagsApiCreateFeatureFlagOpts{
-		Body: optional.NewInterface(opts),
+	return variations
+}
+
+func buildGitDetails(gitDetails *schema.Set) nextgen.GitDetails {
+	var details nextgen.GitDetails
+	for _, v := range gitDetails.List() {
+		details.CommitMsg = v.(map[string]interface{})["commit_msg"].(string)
 	}
 
+	return details
 }
 
 func buildFFReadOpts(d *schema.ResourceData) *nextgen.FeatureFlagsApiGetFeatureFlagOpts {
@@ -0,0 +1,673 @@
+package feature_flag
+
+import (
+	"context"
+	"sort"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceFeatureFlagEnvironment() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for managing the state and targeting of a Feature Flag in an environment.",
+
+		ReadContext:   resourceFeatureFlagEnvironmentRead,
+		UpdateContext: resourceFeatureFlagEnvironmentCreateOrUpdate,
+		DeleteContext: resourceFeatureFlagEnvironmentDelete,
+		CreateContext: resourceFeatureFlagEnvironmentCreateOrUpdate,
+		Importer:      helpers.EnvRelatedResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Feature Flag",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"state": {
+				Description:  "State of the flag in the environment. Valid values are `on` or `off`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(nextgen.ON_FeatureState), string(nextgen.OFF_FeatureState)}, false),
+			},
+			"default_serve": {
+				Description: "What to serve when the flag is on and no rule matches the target",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        serveSchema(),
+			},
+			"rules": {
+				Description: "Rules evaluated in order before the default serve. Each rule serves a variation or a percentage rollout to the targets matching all of its clauses.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_id": {
+							Description: "The unique identifier of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"serve": {
+							Description: "What to serve to targets matching the rule",
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Elem:        serveSchema(),
+						},
+						"clause": {
+							Description: "Conditions a target must all satisfy to match the rule",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Description: "The target attribute to evaluate",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"op": {
+										Description: "The operator, such as `equal`, `in`, `starts_with`, `ends_with`, `contains` or `segmentMatch`",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"values": {
+										Description: "The values compared against the attribute",
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"negate": {
+										Description: "Whether the result of the clause is negated",
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"target_rules": {
+				Description: "Targets and target groups that are always served a given variation",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variation": {
+							Description: "The variation to serve",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"targets": {
+							Description: "Identifiers of the targets served this variation",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"target_groups": {
+							Description: "Identifiers of the target groups served this variation",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func serveSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"variation": {
+				Description: "The variation to serve",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"distribution": {
+				Description: "A percentage rollout across variations, used instead of a single variation",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket_by": {
+							Description: "The target attribute used to bucket targets into variations",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "identifier",
+						},
+						"variation": {
+							Description: "The weight given to each variation",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"variation": {
+										Description: "The variation identifier",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"weight": {
+										Description: "The percentage of targets served this variation",
+										Type:        schema.TypeInt,
+										Required:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type FFEnvironmentQueryParameters struct {
+	Identifier     string
+	OrganizationId string
+	ProjectId      string
+	EnvironmentId  string
+}
+
+func resourceFeatureFlagEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		d.MarkNewResource()
+		return nil
+	}
+
+	qp := buildFFEnvironmentQueryParameters(d)
+
+	resp, httpResp, err := c.FeatureFlagsApi.GetFeatureFlag(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, buildFFEnvironmentReadOpts(qp))
+
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagEnvironment(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagEnvironmentCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildFFEnvironmentQueryParameters(d)
+
+	// The environment settings exist as soon as the flag does, so on create we
+	// diff against what is live to take ownership of any existing targeting.
+	oldRules, _ := d.GetChange("rules")
+	oldTargetRules, _ := d.GetChange("target_rules")
+	if d.Id() == "" {
+		resp, httpResp, err := c.FeatureFlagsApi.GetFeatureFlag(ctx, qp.Identifier, c.AccountId, qp.OrganizationId, qp.ProjectId, buildFFEnvironmentReadOpts(qp))
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		if resp.EnvProperties != nil {
+			oldRules = flattenServingRules(resp.EnvProperties.Rules)
+			oldTargetRules = flattenVariationMap(resp.EnvProperties.VariationMap)
+		}
+	}
+
+	var instructions []nextgen.PatchInstructionInner
+
+	if d.Id() == "" || d.HasChange("state") {
+		instructions = append(instructions, ffInstruction("setFeatureFlagState", map[string]interface{}{
+			"state": d.Get("state").(string),
+		}))
+	}
+
+	if d.Id() == "" || d.HasChange("default_serve") {
+		instructions = append(instructions, ffInstruction("updateDefaultServe", buildServe(d.Get("default_serve").([]interface{}))))
+	}
+
+	instructions = append(instructions, buildRuleInstructions(oldRules.([]interface{}), d.Get("rules").([]interface{}))...)
+	instructions = append(instructions, buildTargetRuleInstructions(oldTargetRules.([]interface{}), d.Get("target_rules").([]interface{}))...)
+
+	if len(instructions) > 0 {
+		_, httpResp, err := c.FeatureFlagsApi.PatchFeature(ctx, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.Identifier, buildFFEnvironmentPatchOpts(qp, instructions))
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+	}
+
+	resp, httpResp, err := waitForFeatureFlag(ctx, c, qp.Identifier, &FFQueryParameters{
+		Identifier:     qp.Identifier,
+		OrganizationId: qp.OrganizationId,
+		ProjectId:      qp.ProjectId,
+	}, buildFFEnvironmentReadOpts(qp))
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagEnvironment(d, &resp, qp)
+
+	return nil
+}
+
+// resourceFeatureFlagEnvironmentDelete turns the flag off in the environment and
+// removes the rules and target mappings managed by this resource.
+func resourceFeatureFlagEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		return nil
+	}
+	qp := buildFFEnvironmentQueryParameters(d)
+
+	instructions := []nextgen.PatchInstructionInner{
+		ffInstruction("setFeatureFlagState", map[string]interface{}{
+			"state": string(nextgen.OFF_FeatureState),
+		}),
+	}
+	instructions = append(instructions, buildRuleInstructions(d.Get("rules").([]interface{}), nil)...)
+	instructions = append(instructions, buildTargetRuleInstructions(d.Get("target_rules").([]interface{}), nil)...)
+
+	_, httpResp, err := c.FeatureFlagsApi.PatchFeature(ctx, c.AccountId, qp.OrganizationId, qp.ProjectId, id, buildFFEnvironmentPatchOpts(qp, instructions))
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return nil
+		}
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return nil
+}
+
+func readFeatureFlagEnvironment(d *schema.ResourceData, flag *nextgen.Feature, qp *FFEnvironmentQueryParameters) {
+	d.SetId(flag.Identifier)
+	d.Set("identifier", flag.Identifier)
+	d.Set("org_id", qp.OrganizationId)
+	d.Set("project_id", qp.ProjectId)
+	d.Set("env_id", qp.EnvironmentId)
+
+	if flag.EnvProperties == nil {
+		return
+	}
+
+	if flag.EnvProperties.State != nil {
+		d.Set("state", string(*flag.EnvProperties.State))
+	}
+	d.Set("default_serve", flattenServe(flag.EnvProperties.DefaultServe))
+	d.Set("rules", flattenServingRules(flag.EnvProperties.Rules))
+	d.Set("target_rules", flattenVariationMap(flag.EnvProperties.VariationMap))
+}
+
+// buildRuleInstructions replaces the old rules with the new ones when they
+// differ. Instructions in a single patch are applied together, so targets
+// never observe the environment without its rules.
+func buildRuleInstructions(oldRules []interface{}, newRules []interface{}) []nextgen.PatchInstructionInner {
+	var instructions []nextgen.PatchInstructionInner
+
+	if rulesEqual(oldRules, newRules) {
+		return nil
+	}
+
+	for _, r := range oldRules {
+		if ruleId := r.(map[string]interface{})["rule_id"].(string); ruleId != "" {
+			instructions = append(instructions, ffInstruction("removeRule", map[string]interface{}{
+				"ruleID": ruleId,
+			}))
+		}
+	}
+
+	for i, rule := range buildServingRules(newRules) {
+		instructions = append(instructions, ffInstruction("addRule", map[string]interface{}{
+			"priority": i + 1,
+			"serve":    rule.Serve,
+			"clauses":  rule.Clauses,
+		}))
+	}
+
+	return instructions
+}
+
+// rulesEqual compares rules ignoring their server assigned identifiers.
+func rulesEqual(oldRules []interface{}, newRules []interface{}) bool {
+	if len(oldRules) != len(newRules) {
+		return false
+	}
+
+	o := buildServingRules(oldRules)
+	n := buildServingRules(newRules)
+	for i := range o {
+		if !serveEqual(o[i].Serve, n[i].Serve) || len(o[i].Clauses) != len(n[i].Clauses) {
+			return false
+		}
+		for j := range o[i].Clauses {
+			oc, nc := o[i].Clauses[j], n[i].Clauses[j]
+			if oc.Attribute != nc.Attribute || oc.Op != nc.Op || oc.Negate != nc.Negate || !stringSlicesEqual(oc.Values, nc.Values) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func serveEqual(a *nextgen.Serve, b *nextgen.Serve) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Variation != b.Variation {
+		return false
+	}
+	if a.Distribution == nil || b.Distribution == nil {
+		return a.Distribution == b.Distribution
+	}
+	if a.Distribution.BucketBy != b.Distribution.BucketBy || len(a.Distribution.Variations) != len(b.Distribution.Variations) {
+		return false
+	}
+	for i := range a.Distribution.Variations {
+		if a.Distribution.Variations[i] != b.Distribution.Variations[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringSlicesEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// buildTargetRuleInstructions adds and removes individual targets and target
+// groups per variation, leaving unchanged mappings untouched.
+func buildTargetRuleInstructions(oldTargetRules []interface{}, newTargetRules []interface{}) []nextgen.PatchInstructionInner {
+	var instructions []nextgen.PatchInstructionInner
+
+	oldTargets, oldGroups := groupTargetRules(oldTargetRules)
+	newTargets, newGroups := groupTargetRules(newTargetRules)
+
+	for _, variation := range sortedKeys(oldTargets, newTargets) {
+		if removed := difference(oldTargets[variation], newTargets[variation]); len(removed) > 0 {
+			instructions = append(instructions, ffInstruction("removeTargetsToVariationTargetMap", map[string]interface{}{
+				"variation": variation,
+				"targets":   removed,
+			}))
+		}
+		if added := difference(newTargets[variation], oldTargets[variation]); len(added) > 0 {
+			instructions = append(instructions, ffInstruction("addTargetsToVariationTargetMap", map[string]interface{}{
+				"variation": variation,
+				"targets":   added,
+			}))
+		}
+	}
+
+	for _, variation := range sortedKeys(oldGroups, newGroups) {
+		if removed := difference(oldGroups[variation], newGroups[variation]); len(removed) > 0 {
+			instructions = append(instructions, ffInstruction("removeSegmentToVariationTargetMap", map[string]interface{}{
+				"variation":      variation,
+				"targetSegments": removed,
+			}))
+		}
+		if added := difference(newGroups[variation], oldGroups[variation]); len(added) > 0 {
+			instructions = append(instructions, ffInstruction("addSegmentToVariationTargetMap", map[string]interface{}{
+				"variation":      variation,
+				"targetSegments": added,
+			}))
+		}
+	}
+
+	return instructions
+}
+
+func groupTargetRules(targetRules []interface{}) (map[string][]string, map[string][]string) {
+	targets := map[string][]string{}
+	groups := map[string][]string{}
+	for _, tr := range targetRules {
+		trMap := tr.(map[string]interface{})
+		variation := trMap["variation"].(string)
+		if v, ok := trMap["targets"].(*schema.Set); ok {
+			targets[variation] = append(targets[variation], helpers.ExpandField(v.List())...)
+		}
+		if v, ok := trMap["target_groups"].(*schema.Set); ok {
+			groups[variation] = append(groups[variation], helpers.ExpandField(v.List())...)
+		}
+	}
+
+	return targets, groups
+}
+
+func sortedKeys(maps ...map[string][]string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a []string, b []string) []string {
+	exclude := map[string]bool{}
+	for _, v := range b {
+		exclude[v] = true
+	}
+
+	var result []string
+	for _, v := range a {
+		if !exclude[v] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func buildServe(serveData []interface{}) *nextgen.Serve {
+	if len(serveData) == 0 || serveData[0] == nil {
+		return nil
+	}
+
+	sMap := serveData[0].(map[string]interface{})
+	serve := &nextgen.Serve{
+		Variation: sMap["variation"].(string),
+	}
+
+	if distributionData, ok := sMap["distribution"].([]interface{}); ok && len(distributionData) > 0 && distributionData[0] != nil {
+		dMap := distributionData[0].(map[string]interface{})
+		distribution := &nextgen.Distribution{
+			BucketBy: dMap["bucket_by"].(string),
+		}
+		for _, v := range dMap["variation"].([]interface{}) {
+			wMap := v.(map[string]interface{})
+			distribution.Variations = append(distribution.Variations, nextgen.WeightedVariation{
+				Variation: wMap["variation"].(string),
+				Weight:    int32(wMap["weight"].(int)),
+			})
+		}
+		serve.Distribution = distribution
+	}
+
+	return serve
+}
+
+func buildServingRules(rulesData []interface{}) []nextgen.ServingRule {
+	var rules []nextgen.ServingRule
+	for i, r := range rulesData {
+		rMap := r.(map[string]interface{})
+		rule := nextgen.ServingRule{
+			Priority: int32(i + 1),
+			Serve:    buildServe(rMap["serve"].([]interface{})),
+		}
+		if ruleId, ok := rMap["rule_id"].(string); ok {
+			rule.RuleId = ruleId
+		}
+		for _, c := range rMap["clause"].([]interface{}) {
+			cMap := c.(map[string]interface{})
+			rule.Clauses = append(rule.Clauses, nextgen.Clause{
+				Attribute: cMap["attribute"].(string),
+				Op:        cMap["op"].(string),
+				Negate:    cMap["negate"].(bool),
+				Values:    helpers.ExpandField(cMap["values"].([]interface{})),
+			})
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func flattenServe(serve *nextgen.Serve) []interface{} {
+	if serve == nil {
+		return nil
+	}
+
+	result := map[string]interface{}{
+		"variation": serve.Variation,
+	}
+
+	if serve.Distribution != nil {
+		var variations []interface{}
+		for _, v := range serve.Distribution.Variations {
+			variations = append(variations, map[string]interface{}{
+				"variation": v.Variation,
+				"weight":    int(v.Weight),
+			})
+		}
+		result["distribution"] = []interface{}{
+			map[string]interface{}{
+				"bucket_by": serve.Distribution.BucketBy,
+				"variation": variations,
+			},
+		}
+	}
+
+	return []interface{}{result}
+}
+
+func flattenServingRules(rules []nextgen.ServingRule) []interface{} {
+	sorted := make([]nextgen.ServingRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	var result []interface{}
+	for _, rule := range sorted {
+		var clauses []interface{}
+		for _, clause := range rule.Clauses {
+			var values []interface{}
+			for _, v := range clause.Values {
+				values = append(values, v)
+			}
+			clauses = append(clauses, map[string]interface{}{
+				"attribute": clause.Attribute,
+				"op":        clause.Op,
+				"values":    values,
+				"negate":    clause.Negate,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"rule_id": rule.RuleId,
+			"serve":   flattenServe(rule.Serve),
+			"clause":  clauses,
+		})
+	}
+
+	return result
+}
+
+func flattenVariationMap(variationMap []nextgen.VariationMap) []interface{} {
+	var result []interface{}
+	for _, vm := range variationMap {
+		if len(vm.Targets) == 0 && len(vm.TargetSegments) == 0 {
+			continue
+		}
+		var targets []interface{}
+		for _, t := range vm.Targets {
+			targets = append(targets, t.Identifier)
+		}
+		var groups []interface{}
+		for _, g := range vm.TargetSegments {
+			groups = append(groups, g)
+		}
+		result = append(result, map[string]interface{}{
+			"variation":     vm.Variation,
+			"targets":       schema.NewSet(schema.HashString, targets),
+			"target_groups": schema.NewSet(schema.HashString, groups),
+		})
+	}
+
+	return result
+}
+
+func buildFFEnvironmentQueryParameters(d *schema.ResourceData) *FFEnvironmentQueryParameters {
+	return &FFEnvironmentQueryParameters{
+		Identifier:     d.Get("identifier").(string),
+		OrganizationId: d.Get("org_id").(string),
+		ProjectId:      d.Get("project_id").(string),
+		EnvironmentId:  d.Get("env_id").(string),
+	}
+}
+
+func buildFFEnvironmentReadOpts(qp *FFEnvironmentQueryParameters) *nextgen.FeatureFlagsApiGetFeatureFlagOpts {
+	return &nextgen.FeatureFlagsApiGetFeatureFlagOpts{
+		EnvironmentIdentifier: optional.NewString(qp.EnvironmentId),
+	}
+}
+
+func buildFFEnvironmentPatchOpts(qp *FFEnvironmentQueryParameters, instructions []nextgen.PatchInstructionInner) *nextgen.FeatureFlagsApiPatchFeatureOpts {
+	return &nextgen.FeatureFlagsApiPatchFeatureOpts{
+		Body: optional.NewInterface(nextgen.GitSyncPatchOperation{
+			Instructions: &instructions,
+		}),
+		EnvironmentIdentifier: optional.NewString(qp.EnvironmentId),
+	}
+}
@@ -0,0 +1,125 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceFeatureFlagEnvironment(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "harness_platform_feature_flag_environment.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFeatureFlagEnvironment(id, "off", "beta"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "state", "off"),
+					resource.TestCheckResourceAttr(resourceName, "default_serve.0.variation", "Disabled"),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.clause.0.values.0", "beta"),
+					resource.TestCheckResourceAttrSet(resourceName, "rules.0.rule_id"),
+				),
+			},
+			{
+				Config: testAccResourceFeatureFlagEnvironment(id, "on", "alpha"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "state", "on"),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.clause.0.values.0", "alpha"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.EnvRelatedResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccResourceFeatureFlagEnvironment(id string, state string, plan string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_environment" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			type = "PreProduction"
+		}
+
+		resource "harness_platform_feature_flag" "test" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+
+			kind = "boolean"
+			name = "%[1]s"
+			identifier = "%[1]s"
+			permanent = false
+
+			default_on_variation = "Enabled"
+			default_off_variation = "Disabled"
+
+			variation {
+				identifier = "Enabled"
+				name = "Enabled"
+				description = "The feature is enabled"
+				value = "true"
+			}
+
+			variation {
+				identifier = "Disabled"
+				name = "Disabled"
+				description = "The feature is disabled"
+				value = "false"
+			}
+		}
+
+		resource "harness_platform_feature_flag_environment" "test" {
+			identifier = harness_platform_feature_flag.test.identifier
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+
+			state = "%[2]s"
+
+			default_serve {
+				variation = "Disabled"
+			}
+
+			rules {
+				serve {
+					variation = "Enabled"
+				}
+				clause {
+					attribute = "plan"
+					op = "in"
+					values = ["%[3]s"]
+				}
+			}
+		}
+`, id, state, plan)
+}
@@ -0,0 +1,217 @@
+package feature_flag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceFeatureFlagTarget() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for managing Feature Flag Targets.",
+
+		ReadContext:   resourceFeatureFlagTargetRead,
+		UpdateContext: resourceFeatureFlagTargetUpdate,
+		DeleteContext: resourceFeatureFlagTargetDelete,
+		CreateContext: resourceFeatureFlagTargetCreate,
+		Importer:      helpers.EnvRelatedResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Target",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the Target",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attributes": {
+				Description: "Attributes of the Target, used by target group and flag rules",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+type TargetQueryParameters struct {
+	Identifier     string
+	OrganizationId string
+	ProjectId      string
+	EnvironmentId  string
+}
+
+func resourceFeatureFlagTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		d.MarkNewResource()
+		return nil
+	}
+
+	qp := buildTargetQueryParameters(d)
+
+	resp, httpResp, err := c.TargetsApi.GetTarget(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId)
+
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTarget(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagTargetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetQueryParameters(d)
+	target := buildTarget(d, c.AccountId)
+
+	httpResp, err := c.TargetsApi.CreateTarget(ctx, target, c.AccountId, qp.OrganizationId)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	var resp nextgen.Target
+	httpResp, err = waitForReadAfterWrite(ctx, func() (*http.Response, error) {
+		var httpResp *http.Response
+		var err error
+		resp, httpResp, err = c.TargetsApi.GetTarget(ctx, qp.Identifier, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId)
+		return httpResp, err
+	})
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTarget(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagTargetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetQueryParameters(d)
+	target := buildTarget(d, c.AccountId)
+
+	resp, httpResp, err := c.TargetsApi.ModifyTarget(ctx, target, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId, d.Id())
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTarget(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagTargetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		return nil
+	}
+	qp := buildTargetQueryParameters(d)
+
+	httpResp, err := c.TargetsApi.DeleteTarget(ctx, id, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return nil
+}
+
+func readFeatureFlagTarget(d *schema.ResourceData, target *nextgen.Target, qp *TargetQueryParameters) {
+	d.SetId(target.Identifier)
+	d.Set("identifier", target.Identifier)
+	d.Set("name", target.Name)
+	d.Set("org_id", qp.OrganizationId)
+	d.Set("project_id", qp.ProjectId)
+	d.Set("env_id", qp.EnvironmentId)
+	d.Set("attributes", flattenAttributes(target.Attributes))
+}
+
+// flattenAttributes converts the free-form JSON attributes of a target into a
+// string map. Non-string values are rendered with their default formatting.
+func flattenAttributes(attributes *interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if attributes == nil {
+		return result
+	}
+
+	if attrs, ok := (*attributes).(map[string]interface{}); ok {
+		for k, v := range attrs {
+			if s, ok := v.(string); ok {
+				result[k] = s
+			} else {
+				result[k] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+
+	return result
+}
+
+func buildTargetQueryParameters(d *schema.ResourceData) *TargetQueryParameters {
+	return &TargetQueryParameters{
+		Identifier:     d.Get("identifier").(string),
+		OrganizationId: d.Get("org_id").(string),
+		ProjectId:      d.Get("project_id").(string),
+		EnvironmentId:  d.Get("env_id").(string),
+	}
+}
+
+func buildTarget(d *schema.ResourceData, accountId string) nextgen.Target {
+	target := nextgen.Target{
+		Account:     accountId,
+		Org:         d.Get("org_id").(string),
+		Project:     d.Get("project_id").(string),
+		Environment: d.Get("env_id").(string),
+		Identifier:  d.Get("identifier").(string),
+		Name:        d.Get("name").(string),
+	}
+
+	if attrs, ok := d.GetOk("attributes"); ok {
+		var attributes interface{} = attrs.(map[string]interface{})
+		target.Attributes = &attributes
+	}
+
+	return target
+}
@@ -0,0 +1,340 @@
+package feature_flag
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceFeatureFlagTargetGroup() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for managing Feature Flag Target Groups.",
+
+		ReadContext:   resourceFeatureFlagTargetGroupRead,
+		UpdateContext: resourceFeatureFlagTargetGroupUpdate,
+		DeleteContext: resourceFeatureFlagTargetGroupDelete,
+		CreateContext: resourceFeatureFlagTargetGroupCreate,
+		Importer:      helpers.EnvRelatedResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the Target Group",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the Target Group",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"included": {
+				Description: "Identifiers of the targets always included in the group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"excluded": {
+				Description: "Identifiers of the targets always excluded from the group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rules": {
+				Description: "Attribute rules; targets matching any of them are included in the group",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clause_id": {
+							Description: "The unique identifier of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"attribute": {
+							Description: "The target attribute to evaluate",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"op": {
+							Description: "The operator, such as `equal`, `equal_sensitive`, `in`, `starts_with`, `ends_with` or `contains`",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"values": {
+							Description: "The values compared against the attribute",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+type TargetGroupQueryParameters struct {
+	Identifier     string
+	OrganizationId string
+	ProjectId      string
+	EnvironmentId  string
+}
+
+type TargetGroupOpts struct {
+	Identifier  string           `json:"identifier"`
+	Name        string           `json:"name"`
+	Environment string           `json:"environment"`
+	Project     string           `json:"project"`
+	Included    []string         `json:"included,omitempty"`
+	Excluded    []string         `json:"excluded,omitempty"`
+	Rules       []nextgen.Clause `json:"rules,omitempty"`
+}
+
+func resourceFeatureFlagTargetGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		d.MarkNewResource()
+		return nil
+	}
+
+	qp := buildTargetGroupQueryParameters(d)
+
+	resp, httpResp, err := c.TargetGroupsApi.GetSegment(ctx, c.AccountId, qp.OrganizationId, id, qp.ProjectId, qp.EnvironmentId)
+
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTargetGroup(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagTargetGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetGroupQueryParameters(d)
+	opts := &TargetGroupOpts{
+		Identifier:  qp.Identifier,
+		Name:        d.Get("name").(string),
+		Environment: qp.EnvironmentId,
+		Project:     qp.ProjectId,
+		Included:    helpers.ExpandField(d.Get("included").(*schema.Set).List()),
+		Excluded:    helpers.ExpandField(d.Get("excluded").(*schema.Set).List()),
+		Rules:       buildClauses(d.Get("rules").([]interface{})),
+	}
+
+	httpResp, err := c.TargetGroupsApi.CreateSegment(ctx, opts, c.AccountId, qp.OrganizationId)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	var resp nextgen.Segment
+	httpResp, err = waitForReadAfterWrite(ctx, func() (*http.Response, error) {
+		var httpResp *http.Response
+		var err error
+		resp, httpResp, err = c.TargetGroupsApi.GetSegment(ctx, c.AccountId, qp.OrganizationId, qp.Identifier, qp.ProjectId, qp.EnvironmentId)
+		return httpResp, err
+	})
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFeatureFlagTargetGroup(d, &resp, qp)
+
+	return nil
+}
+
+func resourceFeatureFlagTargetGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildTargetGroupQueryParameters(d)
+
+	opts := buildTargetGroupPatchOpts(d)
+	if opts == nil {
+		return resourceFeatureFlagTargetGroupRead(ctx, d, meta)
+	}
+
+	_, httpResp, err := c.TargetGroupsApi.PatchSegment(ctx, c.AccountId, qp.OrganizationId, qp.ProjectId, qp.EnvironmentId, d.Id(), opts)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return resourceFeatureFlagTargetGroupRead(ctx, d, meta)
+}
+
+func resourceFeatureFlagTargetGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	id := d.Id()
+	if id == "" {
+		return nil
+	}
+	qp := buildTargetGroupQueryParameters(d)
+
+	httpResp, err := c.TargetGroupsApi.DeleteSegment(ctx, c.AccountId, qp.OrganizationId, id, qp.ProjectId, qp.EnvironmentId)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return nil
+}
+
+func readFeatureFlagTargetGroup(d *schema.ResourceData, segment *nextgen.Segment, qp *TargetGroupQueryParameters) {
+	d.SetId(segment.Identifier)
+	d.Set("identifier", segment.Identifier)
+	d.Set("name", segment.Name)
+	d.Set("org_id", qp.OrganizationId)
+	d.Set("project_id", qp.ProjectId)
+	d.Set("env_id", qp.EnvironmentId)
+	d.Set("included", flattenTargetIdentifiers(segment.Included))
+	d.Set("excluded", flattenTargetIdentifiers(segment.Excluded))
+	d.Set("rules", flattenClauses(segment.Rules))
+}
+
+// buildTargetGroupPatchOpts turns the planned changes into patch instructions.
+// Include and exclude lists are updated incrementally, while changed rules are
+// replaced as a whole in the same patch.
+func buildTargetGroupPatchOpts(d *schema.ResourceData) *nextgen.TargetGroupsApiPatchSegmentOpts {
+	var instructions []nextgen.PatchInstructionInner
+
+	if d.HasChange("name") {
+		instructions = append(instructions, ffInstruction("updateName", map[string]interface{}{
+			"name": d.Get("name").(string),
+		}))
+	}
+
+	for _, list := range []struct{ field, kind string }{{"included", "IncludeList"}, {"excluded", "ExcludeList"}} {
+		field, kind := list.field, list.kind
+		if !d.HasChange(field) {
+			continue
+		}
+		o, n := d.GetChange(field)
+		if removed := o.(*schema.Set).Difference(n.(*schema.Set)); removed.Len() > 0 {
+			instructions = append(instructions, ffInstruction("removeFrom"+kind, map[string]interface{}{
+				"targets": helpers.ExpandField(removed.List()),
+			}))
+		}
+		if added := n.(*schema.Set).Difference(o.(*schema.Set)); added.Len() > 0 {
+			instructions = append(instructions, ffInstruction("addTo"+kind, map[string]interface{}{
+				"targets": helpers.ExpandField(added.List()),
+			}))
+		}
+	}
+
+	if d.HasChange("rules") {
+		o, n := d.GetChange("rules")
+		for _, clause := range buildClauses(o.([]interface{})) {
+			if clause.Id != "" {
+				instructions = append(instructions, ffInstruction("removeClause", map[string]interface{}{
+					"clauseID": clause.Id,
+				}))
+			}
+		}
+		for _, clause := range buildClauses(n.([]interface{})) {
+			instructions = append(instructions, ffInstruction("addClause", map[string]interface{}{
+				"attribute": clause.Attribute,
+				"op":        clause.Op,
+				"values":    clause.Values,
+			}))
+		}
+	}
+
+	if len(instructions) == 0 {
+		return nil
+	}
+
+	return &nextgen.TargetGroupsApiPatchSegmentOpts{
+		Body: optional.NewInterface(nextgen.GitSyncPatchOperation{
+			Instructions: &instructions,
+		}),
+	}
+}
+
+func buildClauses(rules []interface{}) []nextgen.Clause {
+	var clauses []nextgen.Clause
+	for _, r := range rules {
+		rMap := r.(map[string]interface{})
+		clause := nextgen.Clause{
+			Attribute: rMap["attribute"].(string),
+			Op:        rMap["op"].(string),
+			Values:    helpers.ExpandField(rMap["values"].([]interface{})),
+		}
+		if id, ok := rMap["clause_id"].(string); ok {
+			clause.Id = id
+		}
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
+
+func flattenClauses(clauses []nextgen.Clause) []interface{} {
+	var result []interface{}
+	for _, clause := range clauses {
+		result = append(result, map[string]interface{}{
+			"clause_id": clause.Id,
+			"attribute": clause.Attribute,
+			"op":        clause.Op,
+			"values":    clause.Values,
+		})
+	}
+
+	return result
+}
+
+func flattenTargetIdentifiers(targets []nextgen.Target) []string {
+	var result []string
+	for _, target := range targets {
+		result = append(result, target.Identifier)
+	}
+
+	return result
+}
+
+func buildTargetGroupQueryParameters(d *schema.ResourceData) *TargetGroupQueryParameters {
+	return &TargetGroupQueryParameters{
+		Identifier:     d.Get("identifier").(string),
+		OrganizationId: d.Get("org_id").(string),
+		ProjectId:      d.Get("project_id").(string),
+		EnvironmentId:  d.Get("env_id").(string),
+	}
+}
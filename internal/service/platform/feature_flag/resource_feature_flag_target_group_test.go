@@ -0,0 +1,126 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceFeatureFlagTargetGroup(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "harness_platform_feature_flag_target_group.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccFeatureFlagTargetGroupDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFeatureFlagTargetGroup(id, name, "@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "included.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.values.0", "@example.com"),
+				),
+			},
+			{
+				Config: testAccResourceFeatureFlagTargetGroup(id, name, "@harness.io"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.values.0", "@harness.io"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.EnvRelatedResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccGetFeatureFlagTargetGroup(resourceName string, state *terraform.State) (*nextgen.Segment, error) {
+	r := acctest.TestAccGetResource(resourceName, state)
+	c, ctx := acctest.TestAccGetPlatformClientWithContext()
+	id := r.Primary.ID
+	orgId := r.Primary.Attributes["org_id"]
+	projId := r.Primary.Attributes["project_id"]
+	envId := r.Primary.Attributes["env_id"]
+
+	resp, _, err := c.TargetGroupsApi.GetSegment(ctx, c.AccountId, orgId, id, projId, envId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func testAccFeatureFlagTargetGroupDestroy(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		group, _ := testAccGetFeatureFlagTargetGroup(resourceName, state)
+		if group != nil {
+			return fmt.Errorf("Found feature flag target group: %s", group.Identifier)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceFeatureFlagTargetGroup(id string, name string, domain string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_environment" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			type = "PreProduction"
+		}
+
+		resource "harness_platform_feature_flag_target" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+		}
+
+		resource "harness_platform_feature_flag_target_group" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+
+			included = [harness_platform_feature_flag_target.test.id]
+
+			rules {
+				attribute = "email"
+				op = "ends_with"
+				values = ["%[3]s"]
+			}
+		}
+`, id, name, domain)
+}
@@ -0,0 +1,114 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceFeatureFlagTarget(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	updatedName := fmt.Sprintf("%s_updated", name)
+	resourceName := "harness_platform_feature_flag_target.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccFeatureFlagTargetDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFeatureFlagTarget(id, name, "beta"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "attributes.plan", "beta"),
+				),
+			},
+			{
+				Config: testAccResourceFeatureFlagTarget(id, updatedName, "enterprise"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(resourceName, "attributes.plan", "enterprise"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.EnvRelatedResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccGetFeatureFlagTarget(resourceName string, state *terraform.State) (*nextgen.Target, error) {
+	r := acctest.TestAccGetResource(resourceName, state)
+	c, ctx := acctest.TestAccGetPlatformClientWithContext()
+	id := r.Primary.ID
+	orgId := r.Primary.Attributes["org_id"]
+	projId := r.Primary.Attributes["project_id"]
+	envId := r.Primary.Attributes["env_id"]
+
+	resp, _, err := c.TargetsApi.GetTarget(ctx, id, c.AccountId, orgId, projId, envId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func testAccFeatureFlagTargetDestroy(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		target, _ := testAccGetFeatureFlagTarget(resourceName, state)
+		if target != nil {
+			return fmt.Errorf("Found feature flag target: %s", target.Identifier)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceFeatureFlagTarget(id string, name string, plan string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_environment" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			type = "PreProduction"
+		}
+
+		resource "harness_platform_feature_flag_target" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			env_id = harness_platform_environment.test.id
+
+			attributes = {
+				plan = "%[3]s"
+			}
+		}
+`, id, name, plan)
+}
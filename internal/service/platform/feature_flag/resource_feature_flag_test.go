@@ -0,0 +1,149 @@
+package feature_flag_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceFeatureFlag(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	updatedName := fmt.Sprintf("%s_updated", name)
+	resourceName := "harness_platform_feature_flag.test"
+	var createdAt int64
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccFeatureFlagDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFeatureFlag(id, name, "Enabled", "Enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "default_on_variation", "Enabled"),
+					resource.TestCheckResourceAttr(resourceName, "variation.#", "2"),
+					testAccFeatureFlagCreatedAt(resourceName, &createdAt),
+				),
+			},
+			{
+				Config: testAccResourceFeatureFlag(id, updatedName, "Disabled", "Enabled_updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(resourceName, "default_on_variation", "Disabled"),
+					resource.TestCheckResourceAttr(resourceName, "variation.0.name", "Enabled_updated"),
+					testAccFeatureFlagCreatedAt(resourceName, &createdAt),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"git_details"},
+				ImportStateIdFunc:       acctest.ProjectResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccGetFeatureFlag(resourceName string, state *terraform.State) (*nextgen.Feature, error) {
+	r := acctest.TestAccGetResource(resourceName, state)
+	c, ctx := acctest.TestAccGetPlatformClientWithContext()
+	id := r.Primary.ID
+	orgId := r.Primary.Attributes["org_id"]
+	projId := r.Primary.Attributes["project_id"]
+
+	resp, _, err := c.FeatureFlagsApi.GetFeatureFlag(ctx, id, c.AccountId, orgId, projId, &nextgen.FeatureFlagsApiGetFeatureFlagOpts{
+		EnvironmentIdentifier: optional.EmptyString(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// testAccFeatureFlagCreatedAt records the creation time of the flag on the
+// first call and fails if it differs on later ones, which shows an update was
+// done in place rather than by replacing the flag.
+func testAccFeatureFlagCreatedAt(resourceName string, createdAt *int64) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		flag, err := testAccGetFeatureFlag(resourceName, state)
+		if err != nil {
+			return err
+		}
+		if *createdAt == 0 {
+			*createdAt = flag.CreatedAt
+			return nil
+		}
+		if flag.CreatedAt != *createdAt {
+			return fmt.Errorf("feature flag %s was recreated: created at %d, expected %d", flag.Identifier, flag.CreatedAt, *createdAt)
+		}
+		return nil
+	}
+}
+
+func testAccFeatureFlagDestroy(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		flag, _ := testAccGetFeatureFlag(resourceName, state)
+		if flag != nil {
+			return fmt.Errorf("Found feature flag: %s", flag.Identifier)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceFeatureFlag(id string, name string, defaultOn string, variationName string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_feature_flag" "test" {
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+
+			kind = "boolean"
+			name = "%[2]s"
+			identifier = "%[1]s"
+			permanent = false
+
+			default_on_variation = "%[3]s"
+			default_off_variation = "Disabled"
+
+			variation {
+				identifier = "Enabled"
+				name = "%[4]s"
+				description = "The feature is enabled"
+				value = "true"
+			}
+
+			variation {
+				identifier = "Disabled"
+				name = "Disabled"
+				description = "The feature is disabled"
+				value = "false"
+			}
+		}
+`, id, name, defaultOn, variationName)
+}
@@ -0,0 +1,106 @@
+package ff_api_key
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFFApiKey() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for listing the SDK keys of a Feature Flags environment. Secret key values are not returned.",
+
+		ReadContext: dataSourceFFApiKeyRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Description: "Organization Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment Identifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"api_keys": {
+				Description: "SDK API Keys of the environment",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the SDK API Key",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the SDK API Key",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of SDK, either `Server` or `Client`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+const apiKeyPageSize = 100
+
+func dataSourceFFApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	envId := d.Get("env_id").(string)
+
+	var keys []nextgen.CfApiKey
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.APIKeysApi.GetAllAPIKeys(ctx, c.AccountId, orgId, projectId, envId, &nextgen.APIKeysApiGetAllAPIKeysOpts{
+			PageNumber: optional.NewInt32(page),
+			PageSize:   optional.NewInt32(apiKeyPageSize),
+		})
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+
+		keys = append(keys, resp.ApiKeys...)
+		if page+1 >= resp.PageCount || len(resp.ApiKeys) == 0 {
+			break
+		}
+	}
+
+	var apiKeys []interface{}
+	for _, key := range keys {
+		apiKeys = append(apiKeys, map[string]interface{}{
+			"identifier": key.Identifier,
+			"name":       key.Name,
+			"type":       key.Type_,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", orgId, projectId, envId))
+	d.Set("api_keys", apiKeys)
+
+	return nil
+}
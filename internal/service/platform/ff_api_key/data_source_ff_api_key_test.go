@@ -0,0 +1,44 @@
+package ff_api_key_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFFApiKey(t *testing.T) {
+
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "data.harness_platform_ff_api_key.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFFApiKey(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "api_keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "api_keys.0.identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "api_keys.0.type", "Server"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFFApiKey(id string, name string) string {
+	return fmt.Sprintf(`
+		%[1]s
+
+		data "harness_platform_ff_api_key" "test" {
+			org_id = harness_platform_ff_api_key.test.org_id
+			project_id = harness_platform_ff_api_key.test.project_id
+			env_id = harness_platform_ff_api_key.test.env_id
+		}
+`, testAccResourceFFApiKey(id, name, "initial"))
+}
@@ -2,10 +2,12 @@ package ff_api_key
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/antihax/optional"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,9 +19,11 @@ func ResourceFFApiKey() *schema.Resource {
 		Description: "Resource for creating an environment SDK key for Feature Flags.",
 
 		ReadContext:   resourceFFApiKeyRead,
+		UpdateContext: resourceFFApiKeyUpdate,
 		DeleteContext: resourceFFApiKeyDelete,
 		CreateContext: resourceFFApiKeyCreate,
-		Importer:      nil,
+		Importer:      helpers.EnvRelatedResourceImporter,
+		CustomizeDiff: resourceFFApiKeyCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"identifier": {
@@ -32,13 +36,11 @@ func ResourceFFApiKey() *schema.Resource {
 				Description: "Name of the SDK API Key",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"description": {
 				Description: "Description of the SDK API Key",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 			},
 			"api_key": {
 				Description: "The value of the SDK API Key",
@@ -50,7 +52,16 @@ func ResourceFFApiKey() *schema.Resource {
 				Description: "Expiration datetime of the SDK API Key",
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
+			},
+			"rotate_trigger": {
+				Description: "Arbitrary value that rotates the SDK API Key whenever it changes. A replacement key is created before the current one is deleted, so `key_id` and `api_key` change while the resource `id` and `identifier` keep the configured value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"key_id": {
+				Description: "Identifier of the current SDK API Key. It is `identifier` until the key is first rotated, and a new identifier derived from it after each rotation.",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"env_id": {
 				Description: "Environment Identifier",
@@ -82,6 +93,18 @@ func ResourceFFApiKey() *schema.Resource {
 	return resource
 }
 
+// resourceFFApiKeyCustomizeDiff marks the key as unknown when it is about to
+// be rotated, so resources using it are planned against the new value.
+func resourceFFApiKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("rotate_trigger") {
+		return nil
+	}
+	if err := d.SetNewComputed("api_key"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("key_id")
+}
+
 type ApiKeyQueryParameters struct {
 	Identifier     string
 	ProjectId      string
@@ -89,6 +112,12 @@ type ApiKeyQueryParameters struct {
 	OrganizationId string
 }
 
+type ApiKeyUpdateOpts struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ExpiredAt   int    `json:"expiredAt,omitempty"`
+}
+
 type ApiKeyOpts struct {
 	Identifier  string `json:"identifier"`
 	Name        string `json:"name"`
@@ -108,7 +137,7 @@ func resourceFFApiKeyRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	qp := buildFFApiKeyQueryParameters(d)
 
-	resp, httpResp, err := c.APIKeysApi.GetAPIKey(ctx, id, qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId)
+	resp, httpResp, err := c.APIKeysApi.GetAPIKey(ctx, currentFFApiKeyId(d), qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId)
 
 	if err != nil {
 		return helpers.HandleReadApiError(err, d, httpResp)
@@ -127,7 +156,7 @@ func resourceFFApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta in
 		d.MarkNewResource()
 	}
 	qp := buildFFApiKeyQueryParameters(d)
-	opts := buildFFApiKeyOpts(d)
+	opts := buildFFApiKeyOpts(d, qp.Identifier)
 
 	var err error
 	var resp nextgen.CfApiKey
@@ -144,6 +173,49 @@ func resourceFFApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+func resourceFFApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	qp := buildFFApiKeyQueryParameters(d)
+
+	if d.HasChange("rotate_trigger") {
+		return rotateFFApiKey(ctx, c, d, qp)
+	}
+
+	httpResp, err := c.APIKeysApi.UpdateAPIKey(ctx, qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId, currentFFApiKeyId(d), buildFFApiKeyUpdateOpts(d))
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return resourceFFApiKeyRead(ctx, d, meta)
+}
+
+// rotateFFApiKey creates a replacement key under a new identifier derived from
+// the configured one and only deletes the current key once the replacement
+// exists, so consumers are never left without a valid key. The resource ID
+// stays the configured identifier, so the rotation is an in-place update.
+func rotateFFApiKey(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, qp *ApiKeyQueryParameters) diag.Diagnostics {
+	oldId := currentFFApiKeyId(d)
+	newId := fmt.Sprintf("%s_%s", qp.Identifier, utils.RandStringBytes(5))
+
+	resp, httpResp, err := c.APIKeysApi.AddAPIKey(ctx, c.AccountId, qp.OrganizationId, qp.EnvironmentId, qp.ProjectId, buildFFApiKeyOpts(d, newId))
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	readFFApiKey(d, &resp, qp)
+	d.Set("api_key", resp.ApiKey)
+
+	httpResp, err = c.APIKeysApi.DeleteAPIKey(ctx, oldId, qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId)
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return nil
+}
+
 func resourceFFApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
 
@@ -153,7 +225,7 @@ func resourceFFApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta in
 	}
 	qp := buildFFApiKeyQueryParameters(d)
 
-	httpResp, err := c.APIKeysApi.DeleteAPIKey(ctx, d.Id(), qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId)
+	httpResp, err := c.APIKeysApi.DeleteAPIKey(ctx, currentFFApiKeyId(d), qp.ProjectId, qp.EnvironmentId, c.AccountId, qp.OrganizationId)
 	if err != nil {
 		return helpers.HandleApiError(err, d, httpResp)
 	}
@@ -161,9 +233,25 @@ func resourceFFApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// currentFFApiKeyId returns the identifier of the live key, which is the
+// resource ID for keys that were never rotated or were imported.
+func currentFFApiKeyId(d *schema.ResourceData) string {
+	if id := d.Get("key_id").(string); id != "" {
+		return id
+	}
+	return d.Id()
+}
+
 func readFFApiKey(d *schema.ResourceData, apiKey *nextgen.CfApiKey, qp *ApiKeyQueryParameters) {
-	d.SetId(apiKey.Identifier)
-	d.Set("identifier", apiKey.Identifier)
+	// After a rotation the live key identifier differs from the configured one,
+	// so only fill in the ID and identifier when they are unknown.
+	if d.Id() == "" {
+		d.SetId(apiKey.Identifier)
+	}
+	if d.Get("identifier").(string) == "" {
+		d.Set("identifier", apiKey.Identifier)
+	}
+	d.Set("key_id", apiKey.Identifier)
 	d.Set("name", apiKey.Name)
 	if d.IsNewResource() {
 		d.Set("api_key", apiKey.ApiKey)
@@ -185,9 +273,9 @@ func buildFFApiKeyQueryParameters(d *schema.ResourceData) *ApiKeyQueryParameters
 	}
 }
 
-func buildFFApiKeyOpts(d *schema.ResourceData) *nextgen.APIKeysApiAddAPIKeyOpts {
+func buildFFApiKeyOpts(d *schema.ResourceData, identifier string) *nextgen.APIKeysApiAddAPIKeyOpts {
 	opts := &ApiKeyOpts{
-		Identifier:  d.Get("identifier").(string),
+		Identifier:  identifier,
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Type_:       d.Get("type").(string),
@@ -199,3 +287,15 @@ func buildFFApiKeyOpts(d *schema.ResourceData) *nextgen.APIKeysApiAddAPIKeyOpts
 	}
 
 }
+
+func buildFFApiKeyUpdateOpts(d *schema.ResourceData) *nextgen.APIKeysApiUpdateAPIKeyOpts {
+	opts := &ApiKeyUpdateOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ExpiredAt:   d.Get("expired_at").(int),
+	}
+
+	return &nextgen.APIKeysApiUpdateAPIKeyOpts{
+		Body: optional.NewInterface(opts),
+	}
+}
@@ -80,6 +80,19 @@ func DataSourceGitopsAgent() *schema.Resource {
 						},
 					}},
 			},
+			"wait_for_connected": {
+				Description: "Indicates if the read should wait until the agent connects to Harness. Make the data source depend on the installation of the agent, such as a `helm_release`, so it is read once the agent is installed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"wait_timeout": {
+				Description:  "Maximum time to wait for the agent to connect, as a duration such as \"10m\".",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "10m",
+				ValidateFunc: helpers.ValidateDuration,
+			},
+			"health": agentHealthSchema(),
 		},
 	}
 	return resource
@@ -90,6 +103,12 @@ func dataSourceGitopsAgentRead(ctx context.Context, d *schema.ResourceData, meta
 	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
 	agentIdentifier := d.Get("identifier").(string)
 
+	if d.Get("wait_for_connected").(bool) {
+		if err := waitForAgentConnected(ctx, c, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	resp, httpResp, err := c.AgentApi.AgentServiceForServerGet(ctx, agentIdentifier, c.AccountId, &nextgen.AgentsApiAgentServiceForServerGetOpts{
 		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
 		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
@@ -107,5 +126,5 @@ func dataSourceGitopsAgentRead(ctx context.Context, d *schema.ResourceData, meta
 		return nil
 	}
 	readAgent(d, &resp)
-	return nil
+	return readAgentHealth(ctx, c, d, &resp)
 }
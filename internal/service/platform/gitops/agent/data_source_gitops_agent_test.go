@@ -28,6 +28,7 @@ func TestAccDataSourceGitopsAgent(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "identifier", id),
 					resource.TestCheckResourceAttr(resourceName, "org_id", id),
 					resource.TestCheckResourceAttr(resourceName, "project_id", id),
+					resource.TestCheckResourceAttr(resourceName, "health.#", "1"),
 				),
 			},
 		},
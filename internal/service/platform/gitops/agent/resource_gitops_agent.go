@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/antihax/optional"
 	hh "github.com/harness/harness-go-sdk/harness/helpers"
@@ -10,6 +11,7 @@ import (
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -85,6 +87,7 @@ func ResourceGitopsAgent() *schema.Resource {
 						},
 					}},
 			},
+			"health": agentHealthSchema(),
 		},
 	}
 	return resource
@@ -108,7 +111,7 @@ func resourceGitopsAgentCreate(ctx context.Context, d *schema.ResourceData, meta
 		return nil
 	}
 	readAgent(d, &resp)
-	return nil
+	return resourceGitopsAgentRead(ctx, d, meta)
 }
 
 func resourceGitopsAgentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -133,7 +136,7 @@ func resourceGitopsAgentRead(ctx context.Context, d *schema.ResourceData, meta i
 		return nil
 	}
 	readAgent(d, &resp)
-	return nil
+	return readAgentHealth(ctx, c, d, &resp)
 }
 
 func resourceGitopsAgentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -236,3 +239,120 @@ func readAgent(d *schema.ResourceData, agent *nextgen.V1Agent) {
 	metadata = append(metadata, metaDataMap)
 	d.Set("metadata", metadata)
 }
+
+func agentHealthSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Health and connectivity of the agent.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"connection_status": {
+					Description: "Connection status of the agent, either `CONNECTED` or `DISCONNECTED`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"last_heartbeat": {
+					Description: "Time of the last heartbeat received from the agent, in RFC3339 format.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"agent_version": {
+					Description: "Version of the Harness GitOps agent.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"argocd_version": {
+					Description: "Version of Argo CD run by the agent.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"cluster_count": {
+					Description: "Number of clusters registered on the agent.",
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+				"application_count": {
+					Description: "Number of applications deployed by the agent.",
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// readAgentHealth sets the health of the agent. It only warns when the
+// clusters of the agent cannot be listed, leaving cluster_count unset.
+func readAgentHealth(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, agent *nextgen.V1Agent) diag.Diagnostics {
+	clusters, _, err := c.ClustersApi.ClusterServiceListClusters(ctx, nextgen.Servicev1ClusterQuery{
+		AccountIdentifier: c.AccountId,
+		OrgIdentifier:     agent.OrgIdentifier,
+		ProjectIdentifier: agent.ProjectIdentifier,
+		AgentIdentifier:   agent.Identifier,
+		PageSize:          1,
+	})
+
+	var diags diag.Diagnostics
+	health := map[string]interface{}{}
+	if err != nil {
+		// The cluster count is informational, so failing to list clusters
+		// must not fail the read of the agent itself.
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not count the clusters of GitOps agent %s", agent.Identifier),
+			Detail:   err.Error(),
+		})
+	} else {
+		health["cluster_count"] = int(clusters.TotalItems)
+	}
+	if agent.Metadata != nil {
+		health["application_count"] = int(agent.Metadata.DeployedApplicationCount)
+	}
+	if agent.Version != nil {
+		health["agent_version"] = fmt.Sprintf("%s.%s.%s", agent.Version.Major, agent.Version.Minor, agent.Version.Patch)
+	}
+	if agent.Health != nil {
+		if agent.Health.ConnectionStatus != nil {
+			health["connection_status"] = string(*agent.Health.ConnectionStatus)
+		}
+		if !agent.Health.LastHeartbeat.IsZero() {
+			health["last_heartbeat"] = agent.Health.LastHeartbeat.Format(time.RFC3339)
+		}
+		if agent.Health.HarnessGitopsAgent != nil && agent.Health.HarnessGitopsAgent.Version != "" {
+			health["agent_version"] = agent.Health.HarnessGitopsAgent.Version
+		}
+		if agent.Health.ArgoAppController != nil {
+			health["argocd_version"] = agent.Health.ArgoAppController.Version
+		}
+	}
+
+	d.Set("health", []interface{}{health})
+	return diags
+}
+
+// waitForAgentConnected polls the agent until it reports CONNECTED, which
+// happens once the agent installed in the cluster registers with Harness.
+func waitForAgentConnected(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData) error {
+	agentIdentifier := d.Get("identifier").(string)
+	timeout, _ := time.ParseDuration(d.Get("wait_timeout").(string))
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, httpResp, err := c.AgentApi.AgentServiceForServerGet(ctx, agentIdentifier, c.AccountId, &nextgen.AgentsApiAgentServiceForServerGetOpts{
+			OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+			ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+		})
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode >= 500 {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		if resp.Health == nil || resp.Health.ConnectionStatus == nil || *resp.Health.ConnectionStatus != nextgen.CONNECTED_V1ConnectedStatus {
+			return retry.RetryableError(fmt.Errorf("waiting for GitOps agent %s to connect", agentIdentifier))
+		}
+
+		return nil
+	})
+}
@@ -34,6 +34,8 @@ func TestAccResourceGitopsAgent(t *testing.T) {
 				Config: testAccResourceGitopsAgentAccountLevel(id, accountId, agentName, namespace),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", agentName),
+					resource.TestCheckResourceAttr(resourceName, "health.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "health.0.cluster_count", "0"),
 				),
 			},
 			{
@@ -1,13 +1,19 @@
 package agent_yaml
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"strings"
+
 	"github.com/antihax/optional"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
 )
 
 func DataSourceGitopsAgentDeployYaml() *schema.Resource {
@@ -47,6 +53,15 @@ func DataSourceGitopsAgentDeployYaml() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"manifests": {
+				Description: "Deployment YAML of the GitOps agent split into one entry per Kubernetes manifest. It includes the Secret holding the agent token.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 	return resource
@@ -73,7 +88,13 @@ func dataSourceGitopsAgentDeployYamlRead(ctx context.Context, d *schema.Resource
 		d.MarkNewResource()
 		return nil
 	}
+	manifests, err := splitManifests(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	readAgentYaml(agentIdentifier, d, resp)
+	d.Set("manifests", manifests)
 	return nil
 }
 
@@ -81,3 +102,34 @@ func readAgentYaml(agentIdentifier string, d *schema.ResourceData, yaml string)
 	d.SetId(agentIdentifier)
 	d.Set("yaml", yaml)
 }
+
+// splitManifests breaks the multi-document deployment YAML into one string
+// per non-empty Kubernetes manifest.
+func splitManifests(deployYaml string) ([]string, error) {
+	var manifests []string
+	decoder := yaml.NewDecoder(strings.NewReader(deployYaml))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode || len(doc.Content[0].Content) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&doc); err != nil {
+			return nil, err
+		}
+		encoder.Close()
+		manifests = append(manifests, buf.String())
+	}
+
+	return manifests, nil
+}
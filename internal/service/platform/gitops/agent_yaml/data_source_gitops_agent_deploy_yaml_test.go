@@ -25,6 +25,7 @@ func TestAccDataSourceGitopsAgentDeployYaml(t *testing.T) {
 				Config: testAccDataSourceGitopsAgentDeployYaml(agentId, accountId, agentId, namespace),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resourceName, "yaml"),
+					resource.TestCheckResourceAttrSet(resourceName, "manifests.0"),
 				),
 			},
 		},
@@ -0,0 +1,37 @@
+package agent_yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDeployYaml = `---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: gitops-agent
+type: Opaque
+data:
+  GITOPS_AGENT_TOKEN: c2VjcmV0LXRva2Vu
+---
+# an empty document
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: gitops-agent
+data:
+  GITOPS_SERVICE_HTTP: https://app.harness.io/gitops/api/v1
+`
+
+func TestSplitManifests(t *testing.T) {
+	manifests, err := splitManifests(testDeployYaml)
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+	require.Contains(t, manifests[0], "kind: Secret")
+	require.Contains(t, manifests[1], "kind: ConfigMap")
+
+	_, err = splitManifests("kind: [")
+	require.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceGitopsApplicationResources() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for fetching the live resource tree of a Harness GitOps Application.",
+
+		ReadContext: dataSourceGitopsApplicationResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Description: "Organization identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"agent_id": {
+				Description: "Agent identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"identifier": {
+				Description: "Identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"kinds": {
+				Description: "Only return resources of these kinds, such as `Service` or `Ingress`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"labels": {
+				Description:  "Only return resources carrying all of these labels. Labels are read from the live manifests, so this requires `include_manifests` to be true.",
+				Type:         schema.TypeMap,
+				Optional:     true,
+				RequiredWith: []string{"include_manifests"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"include_manifests": {
+				Description: "Indicates if the live manifest of each resource should be returned. This makes one additional request per resource left after filtering by `kinds`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"resources": {
+				Description: "Resources of the GitOps application.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Description: "API group of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version": {
+							Description: "API version of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "Kind of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"namespace": {
+							Description: "Namespace of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"uid": {
+							Description: "Kubernetes UID of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"health_status": {
+							Description: "Health status of the resource, such as `Healthy` or `Degraded`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"health_message": {
+							Description: "Message explaining the health status of the resource.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"sync_status": {
+							Description: "Sync status of the resource, such as `Synced` or `OutOfSync`. Empty for resources that are not managed directly by the application.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"images": {
+							Description: "Container images referenced by the resource.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"external_urls": {
+							Description: "External URLs exposed by the resource, such as ingress hosts.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"manifest": {
+							Description: "Live manifest of the resource in JSON format. Only set when `include_manifests` is true. It is sensitive as it includes the data of secrets.",
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+	return resource
+}
+
+func dataSourceGitopsApplicationResourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	agentIdentifier := d.Get("agent_id").(string)
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	name := d.Get("identifier").(string)
+
+	labels := d.Get("labels").(map[string]interface{})
+	includeManifests := d.Get("include_manifests").(bool)
+	if len(labels) > 0 && !includeManifests {
+		return diag.Errorf("labels can only be matched when include_manifests is true")
+	}
+
+	tree, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceResourceTree(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceResourceTreeOpts{})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	app, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	syncStatuses := map[string]string{}
+	if status := applicationStatus(&app); status != nil {
+		for _, r := range status.Resources {
+			syncStatuses[resourceKey(r.Group, r.Kind, r.Namespace, r.Name)] = r.Status
+		}
+	}
+
+	kinds := d.Get("kinds").(*schema.Set)
+
+	var resources []interface{}
+	for _, node := range tree.Nodes {
+		ref := node.ResourceRef
+		if ref == nil || (kinds.Len() > 0 && !kinds.Contains(ref.Kind)) {
+			continue
+		}
+
+		var manifest string
+		if includeManifests {
+			resp, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGetResource(ctx, agentIdentifier, name, &nextgen.ApplicationsApiAgentApplicationServiceGetResourceOpts{
+				AccountIdentifier:   optional.NewString(c.AccountId),
+				OrgIdentifier:       optional.NewString(orgIdentifier),
+				ProjectIdentifier:   optional.NewString(projectIdentifier),
+				RequestNamespace:    optional.NewString(ref.Namespace),
+				RequestResourceName: optional.NewString(ref.Name),
+				RequestVersion:      optional.NewString(ref.Version),
+				RequestGroup:        optional.NewString(ref.Group),
+				RequestKind:         optional.NewString(ref.Kind),
+			})
+			if err != nil {
+				return helpers.HandleApiError(err, d, httpResp)
+			}
+			manifest = resp.Manifest
+		}
+
+		if len(labels) > 0 {
+			matches, err := manifestHasLabels(manifest, labels)
+			if err != nil {
+				return diag.Errorf("reading labels of %s %s: %s", ref.Kind, ref.Name, err)
+			}
+			if !matches {
+				continue
+			}
+		}
+
+		resource := map[string]interface{}{
+			"group":       ref.Group,
+			"version":     ref.Version,
+			"kind":        ref.Kind,
+			"namespace":   ref.Namespace,
+			"name":        ref.Name,
+			"uid":         ref.Uid,
+			"sync_status": syncStatuses[resourceKey(ref.Group, ref.Kind, ref.Namespace, ref.Name)],
+			"images":      node.Images,
+		}
+		if node.Health != nil {
+			resource["health_status"] = node.Health.Status
+			resource["health_message"] = node.Health.Message
+		}
+		if node.NetworkingInfo != nil {
+			resource["external_urls"] = node.NetworkingInfo.ExternalURLs
+		}
+		if includeManifests {
+			resource["manifest"] = manifest
+		}
+		resources = append(resources, resource)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", agentIdentifier, name))
+	d.Set("resources", resources)
+	return nil
+}
+
+func resourceKey(group, kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", group, kind, namespace, name)
+}
+
+// manifestHasLabels reports whether the metadata of a live JSON manifest
+// carries every wanted label with the same value.
+func manifestHasLabels(manifest string, wanted map[string]interface{}) (bool, error) {
+	if manifest == "" {
+		return false, nil
+	}
+
+	var object struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(manifest), &object); err != nil {
+		return false, err
+	}
+
+	for k, v := range wanted {
+		if object.Metadata.Labels[k] != v.(string) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package applications_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGitopsApplicationResources(t *testing.T) {
+	id := strings.ToLower(fmt.Sprintf("%s%s", t.Name(), utils.RandStringBytes(5)))
+	id = strings.ReplaceAll(id, "_", "")
+	name := id
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	clusterServer := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_SERVER_APP")
+	clusterId := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_ID")
+	repoId := os.Getenv("HARNESS_TEST_GITOPS_REPO_ID")
+	repo := os.Getenv("HARNESS_TEST_GITOPS_REPO")
+	clusterName := id
+	namespace := "test"
+	resourceName := "data.harness_platform_gitops_application_resources.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGitopsApplicationResources(id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "resources.0.kind", "Service"),
+					resource.TestCheckResourceAttr(resourceName, "resources.0.sync_status", "Synced"),
+					resource.TestCheckResourceAttrSet(resourceName, "resources.0.manifest"),
+					resource.TestCheckResourceAttr("data.harness_platform_gitops_application_resources.summary", "resources.0.kind", "Service"),
+					resource.TestCheckResourceAttr("data.harness_platform_gitops_application_resources.summary", "resources.0.manifest", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGitopsApplicationResources(id string, accountId string, name string, agentId string, clusterName string, namespace string, clusterServer string, clusterId string, repo string, repoId string) string {
+	return fmt.Sprintf(`
+		%s
+
+		data "harness_platform_gitops_application_resources" "test" {
+			identifier = harness_platform_gitops_applications.test.id
+			agent_id = harness_platform_gitops_applications.test.agent_id
+			org_id = harness_platform_gitops_applications.test.org_id
+			project_id = harness_platform_gitops_applications.test.project_id
+			kinds = ["Service"]
+			include_manifests = true
+		}
+
+		data "harness_platform_gitops_application_resources" "summary" {
+			identifier = harness_platform_gitops_applications.test.id
+			agent_id = harness_platform_gitops_applications.test.agent_id
+			org_id = harness_platform_gitops_applications.test.org_id
+			project_id = harness_platform_gitops_applications.test.project_id
+			kinds = ["Service"]
+		}
+		`, testAccResourceGitopsApplicationSyncAndWait(id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId))
+}
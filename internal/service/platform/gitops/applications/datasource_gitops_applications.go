@@ -121,6 +121,21 @@ func DataSourceGitopsApplications() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"sync_status": {
+				Description: "Sync status of the GitOps application, such as `Synced` or `OutOfSync`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"health_status": {
+				Description: "Health status of the GitOps application, such as `Healthy`, `Progressing` or `Degraded`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"synced_revision": {
+				Description: "Revision the GitOps application is synced to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"application": {
 				Description: "Definition of the GitOps application resource.",
 				Type:        schema.TypeList,
@@ -2,11 +2,15 @@ package applications
 
 import (
 	"context"
+	"fmt"
+	"time"
+
 	"github.com/antihax/optional"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -126,6 +130,37 @@ func ResourceGitopsApplication() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"sync_on_apply": {
+				Description: "Indicates if a sync of the GitOps application should be triggered after it is created or updated.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"wait_for_health": {
+				Description: "Indicates if apply should wait until the GitOps application is `Synced` and `Healthy`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"wait_timeout": {
+				Description:  "Maximum time to wait for the GitOps application to become `Synced` and `Healthy`, as a duration such as \"10m\". Defaults to 10m.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: helpers.ValidateDuration,
+			},
+			"sync_status": {
+				Description: "Sync status of the GitOps application, such as `Synced` or `OutOfSync`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"health_status": {
+				Description: "Health status of the GitOps application, such as `Healthy`, `Progressing` or `Degraded`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"synced_revision": {
+				Description: "Revision the GitOps application is synced to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"application": {
 				Description: "Definition of the GitOps application resource.",
 				Type:        schema.TypeList,
@@ -726,7 +761,7 @@ func resourceGitopsApplicationCreate(ctx context.Context, d *schema.ResourceData
 		return nil
 	}
 	setApplication(d, &resp)
-	return nil
+	return syncAndWaitForApplication(ctx, c, d)
 }
 
 func resourceGitopsApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -821,7 +856,7 @@ func resourceGitopsApplicationUpdate(ctx context.Context, d *schema.ResourceData
 		return nil
 	}
 	setApplication(d, &resp)
-	return nil
+	return syncAndWaitForApplication(ctx, c, d)
 }
 
 func resourceGitopsApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -875,6 +910,7 @@ func setApplication(d *schema.ResourceData, app *nextgen.Servicev1Application) {
 	d.Set("cluster_id", app.ClusterIdentifier)
 	d.Set("repo_id", app.RepoIdentifier)
 	d.Set("name", app.Name)
+	setApplicationStatus(d, app)
 
 	if app.App != nil {
 		var applicationList = []interface{}{}
@@ -1507,3 +1543,111 @@ func buildApplicationRequest(d *schema.ResourceData) *nextgen.ApplicationsApplic
 		Spec:     &spec,
 	}
 }
+
+const (
+	syncStatusSynced    = "Synced"
+	healthStatusHealthy = "Healthy"
+)
+
+// syncAndWaitForApplication triggers a sync when sync_on_apply is set and,
+// when wait_for_health is set, polls the application until it reports Synced
+// and Healthy so dependent resources only run once it is actually deployed.
+// After a sync, the application is only waited for once that sync has
+// finished, as its status describes the previous one until then.
+func syncAndWaitForApplication(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData) diag.Diagnostics {
+	agentIdentifier := d.Get("agent_id").(string)
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	name := d.Get("identifier").(string)
+
+	synced := d.Get("sync_on_apply").(bool)
+	var previousStartedAt *nextgen.V1Time
+	if synced {
+		app, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{})
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		if status := applicationStatus(&app); status != nil && status.OperationState != nil {
+			previousStartedAt = status.OperationState.StartedAt
+		}
+
+		resp, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceSync(ctx, nextgen.ApplicationsApplicationSyncRequest{
+			Name: name,
+		}, c.AccountId, orgIdentifier, projectIdentifier, agentIdentifier, name)
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		setApplicationStatus(d, &resp)
+	}
+
+	if !d.Get("wait_for_health").(bool) {
+		return nil
+	}
+
+	timeout := defaultWaitTimeout
+	if attr, ok := d.GetOk("wait_timeout"); ok {
+		timeout, _ = time.ParseDuration(attr.(string))
+	}
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{
+			QueryRefresh: optional.NewString("normal"),
+			QueryRepo:    optional.NewString(d.Get("repo_id").(string)),
+		})
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode >= 500 {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		setApplicationStatus(d, &resp)
+		if synced {
+			status := applicationStatus(&resp)
+			if status == nil || status.OperationState == nil || !operationStartedSince(status.OperationState, previousStartedAt) || status.OperationState.FinishedAt == nil {
+				return retry.RetryableError(fmt.Errorf("waiting for sync of GitOps application %s to finish", name))
+			}
+			if phase := status.OperationState.Phase; phase == "Failed" || phase == "Error" {
+				return retry.NonRetryableError(fmt.Errorf("sync of GitOps application %s ended with phase %s: %s", name, phase, status.OperationState.Message))
+			}
+		}
+
+		syncStatus, healthStatus := d.Get("sync_status").(string), d.Get("health_status").(string)
+		if syncStatus != syncStatusSynced || healthStatus != healthStatusHealthy {
+			return retry.RetryableError(fmt.Errorf("waiting for GitOps application %s to be %s and %s, currently %s and %s", name, syncStatusSynced, healthStatusHealthy, syncStatus, healthStatus))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func applicationStatus(app *nextgen.Servicev1Application) *nextgen.ApplicationsApplicationStatus {
+	if app == nil || app.App == nil {
+		return nil
+	}
+
+	return app.App.Status
+}
+
+func setApplicationStatus(d *schema.ResourceData, app *nextgen.Servicev1Application) {
+	var syncStatus, healthStatus, syncedRevision string
+	if status := applicationStatus(app); status != nil {
+		if status.Sync != nil {
+			syncStatus = status.Sync.Status
+			syncedRevision = status.Sync.Revision
+		}
+		if status.Health != nil {
+			healthStatus = status.Health.Status
+		}
+	}
+
+	d.Set("sync_status", syncStatus)
+	d.Set("health_status", healthStatus)
+	d.Set("synced_revision", syncedRevision)
+}
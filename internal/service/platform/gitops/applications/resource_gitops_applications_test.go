@@ -103,6 +103,37 @@ func TestAccResourceGitopsApplication_KustomizeApp(t *testing.T) {
 	})
 }
 
+func TestAccResourceGitopsApplication_SyncAndWait(t *testing.T) {
+	id := strings.ToLower(fmt.Sprintf("%s%s", t.Name(), utils.RandStringBytes(5)))
+	id = strings.ReplaceAll(id, "_", "")
+	name := id
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	clusterServer := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_SERVER_APP")
+	clusterId := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_ID")
+	repoId := os.Getenv("HARNESS_TEST_GITOPS_REPO_ID")
+	clusterName := id
+	namespace := "test"
+	repo := os.Getenv("HARNESS_TEST_GITOPS_REPO")
+	resourceName := "harness_platform_gitops_applications.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccResourceGitopsApplicationDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGitopsApplicationSyncAndWait(id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "sync_status", "Synced"),
+					resource.TestCheckResourceAttr(resourceName, "health_status", "Healthy"),
+					resource.TestCheckResourceAttrSet(resourceName, "synced_revision"),
+				),
+			},
+		},
+	})
+}
+
 func testAccResourceGitopsApplicationDestroy(resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		application, _ := testAccGetApplication(resourceName, state)
@@ -286,3 +317,13 @@ func testAccResourceGitopsApplicationKustomize(id string, accountId string, name
 		}
 		`, id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId)
 }
+
+func testAccResourceGitopsApplicationSyncAndWait(id string, accountId string, name string, agentId string, clusterName string, namespace string, clusterServer string, clusterId string, repo string, repoId string) string {
+	config := testAccResourceGitopsApplicationHelm(id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId)
+	agent := fmt.Sprintf("agent_id = \"%s\"", agentId)
+
+	return strings.Replace(config, agent, agent+`
+				sync_on_apply = true
+				wait_for_health = true
+				wait_timeout = "5m"`, 1)
+}
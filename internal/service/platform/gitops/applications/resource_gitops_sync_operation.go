@@ -0,0 +1,289 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antihax/optional"
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const defaultWaitTimeout = 10 * time.Minute
+
+func ResourceGitopsSyncOperation() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for performing a one-off sync of a Harness GitOps application. A new sync is performed whenever `triggers` or any other argument changes, and the result of the operation is recorded in state.",
+
+		CreateContext: resourceGitopsSyncOperationCreate,
+		ReadContext:   resourceGitopsSyncOperationRead,
+		DeleteContext: resourceGitopsSyncOperationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Description: "Account identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"org_id": {
+				Description: "Organization identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"agent_id": {
+				Description: "Agent identifier of the GitOps application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"application_id": {
+				Description: "Identifier of the GitOps application to sync.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, performs a new sync.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"revision": {
+				Description: "Revision to sync to. Defaults to the target revision of the application.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"prune": {
+				Description: "Delete resources that are no longer defined in the source.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"dry_run": {
+				Description: "Perform the sync without applying any changes.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"resources": {
+				Description: "Resources to sync. All resources of the application are synced if empty.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Description: "API group of the resource.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"kind": {
+							Description: "Kind of the resource.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"name": {
+							Description: "Name of the resource.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"namespace": {
+							Description: "Namespace of the resource.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"wait_timeout": {
+				Description:  "Maximum time to wait for the sync operation to finish, as a duration such as \"15m\". Defaults to 10 minutes.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: helpers.ValidateDuration,
+			},
+			"phase": {
+				Description: "Phase the sync operation ended in, such as `Succeeded` or `Failed`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"message": {
+				Description: "Message of the sync operation.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"synced_revision": {
+				Description: "Revision the application was synced to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"started_at": {
+				Description: "Time the sync operation started, in seconds since the Unix epoch.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"finished_at": {
+				Description: "Time the sync operation finished, in seconds since the Unix epoch.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+	return resource
+}
+
+func resourceGitopsSyncOperationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+	agentIdentifier := d.Get("agent_id").(string)
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	name := d.Get("application_id").(string)
+
+	// Fail early with the active windows rather than the generic error the
+	// agent returns when a sync window blocks the operation.
+	windows, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGetApplicationSyncWindows(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if !windows.CanSync {
+		var active []string
+		for _, w := range windows.ActiveWindows {
+			active = append(active, fmt.Sprintf("%s %q for %s", w.Kind, w.Schedule, w.Duration))
+		}
+		return diag.Errorf("sync of GitOps application %s is blocked by sync windows: %v", name, active)
+	}
+
+	// The operation state of the application describes the last sync until
+	// the agent starts this one, which is told apart by its start time.
+	app, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	var previousStartedAt *nextgen.V1Time
+	if status := applicationStatus(&app); status != nil && status.OperationState != nil {
+		previousStartedAt = status.OperationState.StartedAt
+	}
+
+	var resources []nextgen.ApplicationsSyncOperationResource
+	for _, r := range d.Get("resources").([]interface{}) {
+		resource := r.(map[string]interface{})
+		resources = append(resources, nextgen.ApplicationsSyncOperationResource{
+			Group:     resource["group"].(string),
+			Kind:      resource["kind"].(string),
+			Name:      resource["name"].(string),
+			Namespace: resource["namespace"].(string),
+		})
+	}
+
+	_, httpResp, err = c.ApplicationsApiService.AgentApplicationServiceSync(ctx, nextgen.ApplicationsApplicationSyncRequest{
+		Name:      name,
+		Revision:  d.Get("revision").(string),
+		Prune:     d.Get("prune").(bool),
+		DryRun:    d.Get("dry_run").(bool),
+		Resources: resources,
+	}, c.AccountId, orgIdentifier, projectIdentifier, agentIdentifier, name)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	d.SetId(id.UniqueId())
+
+	timeout := defaultWaitTimeout
+	if attr, ok := d.GetOk("wait_timeout"); ok {
+		timeout, _ = time.ParseDuration(attr.(string))
+	}
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, agentIdentifier, name, c.AccountId, orgIdentifier, projectIdentifier, &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{
+			QueryRefresh: optional.NewString("normal"),
+		})
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode >= 500 {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		status := applicationStatus(&resp)
+		if status == nil || status.OperationState == nil || !operationStartedSince(status.OperationState, previousStartedAt) || status.OperationState.FinishedAt == nil {
+			return retry.RetryableError(fmt.Errorf("waiting for sync of GitOps application %s to finish", name))
+		}
+
+		setSyncOperationState(d, status.OperationState)
+		if phase := status.OperationState.Phase; phase == "Failed" || phase == "Error" {
+			return retry.NonRetryableError(fmt.Errorf("sync of GitOps application %s ended with phase %s: %s", name, phase, status.OperationState.Message))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGitopsSyncOperationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	// The recorded result belongs to this operation, so reading only checks
+	// that the application still exists.
+	_, httpResp, err := c.ApplicationsApiService.AgentApplicationServiceGet(ctx, d.Get("agent_id").(string), d.Get("application_id").(string), c.AccountId, d.Get("org_id").(string), d.Get("project_id").(string), &nextgen.ApplicationsApiAgentApplicationServiceGetOpts{})
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+	return nil
+}
+
+func resourceGitopsSyncOperationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A sync cannot be undone; removing the resource only forgets the result.
+	d.SetId("")
+	return nil
+}
+
+// operationStartedSince reports whether the operation is a later one than the
+// operation that started at previous, if any. Both times come from the agent,
+// so they are compared as is rather than against the local clock.
+func operationStartedSince(state *nextgen.ApplicationsOperationState, previous *nextgen.V1Time) bool {
+	if state.StartedAt == nil {
+		return false
+	}
+	return previous == nil || *state.StartedAt != *previous
+}
+
+func setSyncOperationState(d *schema.ResourceData, state *nextgen.ApplicationsOperationState) {
+	d.Set("phase", state.Phase)
+	d.Set("message", state.Message)
+	if state.SyncResult != nil {
+		d.Set("synced_revision", state.SyncResult.Revision)
+	}
+	if state.StartedAt != nil {
+		d.Set("started_at", state.StartedAt.Seconds)
+	}
+	if state.FinishedAt != nil {
+		d.Set("finished_at", state.FinishedAt.Seconds)
+	}
+}
@@ -0,0 +1,66 @@
+package applications_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGitopsSyncOperation(t *testing.T) {
+	id := strings.ToLower(fmt.Sprintf("%s%s", t.Name(), utils.RandStringBytes(5)))
+	id = strings.ReplaceAll(id, "_", "")
+	name := id
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	clusterServer := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_SERVER_APP")
+	clusterId := os.Getenv("HARNESS_TEST_GITOPS_CLUSTER_ID")
+	repoId := os.Getenv("HARNESS_TEST_GITOPS_REPO_ID")
+	clusterName := id
+	namespace := "test"
+	repo := os.Getenv("HARNESS_TEST_GITOPS_REPO")
+	resourceName := "harness_platform_gitops_sync_operation.test"
+	application := testAccResourceGitopsApplicationSyncAndWait(id, accountId, name, agentId, clusterName, namespace, clusterServer, clusterId, repo, repoId)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: application + testAccResourceGitopsSyncOperation("1", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "phase", "Succeeded"),
+					resource.TestCheckResourceAttrSet(resourceName, "synced_revision"),
+					resource.TestCheckResourceAttrSet(resourceName, "finished_at"),
+				),
+			},
+			{
+				Config: application + testAccResourceGitopsSyncOperation("2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "phase", "Succeeded"),
+					resource.TestCheckResourceAttr(resourceName, "triggers.change", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceGitopsSyncOperation(change string, dryRun bool) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_gitops_sync_operation" "test" {
+			account_id = harness_platform_gitops_applications.test.account_id
+			org_id = harness_platform_gitops_applications.test.org_id
+			project_id = harness_platform_gitops_applications.test.project_id
+			agent_id = harness_platform_gitops_applications.test.agent_id
+			application_id = harness_platform_gitops_applications.test.identifier
+			prune = true
+			dry_run = %[2]t
+			triggers = {
+				change = "%[1]s"
+			}
+		}
+	`, change, dryRun)
+}
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/antihax/optional"
 	hh "github.com/harness/harness-go-sdk/harness/helpers"
@@ -10,6 +11,7 @@ import (
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceGitopsCluster() *schema.Resource {
@@ -21,6 +23,7 @@ func ResourceGitopsCluster() *schema.Resource {
 		UpdateContext: resourceGitopsClusterUpdate,
 		DeleteContext: resourceGitopsClusterDelete,
 		Importer:      helpers.GitopsAgentResourceImporter,
+		CustomizeDiff: validateAuthPreset,
 
 		Schema: map[string]*schema.Schema{
 			"account_id": {
@@ -275,6 +278,43 @@ func ResourceGitopsCluster() *schema.Resource {
 													Type:        schema.TypeString,
 													Optional:    true,
 												},
+												"auth_preset": {
+													Description:   "Cloud IAM authentication preset. Generates the exec provider config for `argocd-k8s-auth`, so no static credentials are stored. Conflicts with `exec_provider_config`.",
+													Type:          schema.TypeList,
+													Optional:      true,
+													MaxItems:      1,
+													ConflictsWith: []string{"request.0.cluster.0.config.0.exec_provider_config"},
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"provider": {
+																Description:  "Cloud provider of the cluster. Valid values are `EKS`, `GKE` and `AKS`.",
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validation.StringInSlice([]string{"EKS", "GKE", "AKS"}, false),
+															},
+															"aws_cluster_name": {
+																Description: "Name of the EKS cluster. Required for `EKS`.",
+																Type:        schema.TypeString,
+																Optional:    true,
+															},
+															"aws_role_arn": {
+																Description: "IAM role assumed to access the EKS cluster.",
+																Type:        schema.TypeString,
+																Optional:    true,
+															},
+															"azure_client_id": {
+																Description: "Client ID of the Azure workload identity used to access the AKS cluster.",
+																Type:        schema.TypeString,
+																Optional:    true,
+															},
+															"azure_tenant_id": {
+																Description: "Tenant ID of the Azure workload identity used to access the AKS cluster.",
+																Type:        schema.TypeString,
+																Optional:    true,
+															},
+														},
+													},
+												},
 											},
 										},
 									},
@@ -600,7 +640,12 @@ func setClusterDetails(d *schema.ResourceData, cl *nextgen.Servicev1Cluster) {
 			}
 			config["role_a_r_n"] = cl.Cluster.Config.RoleARN
 			config["aws_cluster_name"] = cl.Cluster.Config.AwsClusterName
-			if cl.Cluster.Config.ExecProviderConfig != nil {
+			// The exec provider config of a preset is generated, so report it as
+			// the preset it was generated from, unless it is configured as is.
+			_, hasExecProviderConfig := d.GetOk("request.0.cluster.0.config.0.exec_provider_config")
+			if preset := flattenAuthPreset(cl.Cluster.Config.ExecProviderConfig); preset != nil && !hasExecProviderConfig {
+				config["auth_preset"] = []interface{}{preset}
+			} else if cl.Cluster.Config.ExecProviderConfig != nil {
 				execProviderConfigList := []interface{}{}
 				execProviderConfig := map[string]interface{}{}
 				execProviderConfig["command"] = cl.Cluster.Config.ExecProviderConfig.Command
@@ -803,6 +848,10 @@ func buildClusterDetails(d *schema.ResourceData) *nextgen.ClustersCluster {
 				if clusterConfig["cluster_connection_type"] != nil {
 					clusterDetails.Config.ClusterConnectionType = clusterConfig["cluster_connection_type"].(string)
 				}
+
+				if clusterConfig["auth_preset"] != nil && len(clusterConfig["auth_preset"].([]interface{})) > 0 {
+					clusterDetails.Config.ExecProviderConfig = buildAuthPresetExecProviderConfig(clusterConfig["auth_preset"].([]interface{})[0].(map[string]interface{}))
+				}
 			}
 
 			if requestCluster["namespaces"] != nil {
@@ -876,3 +925,95 @@ func buildClusterDetails(d *schema.ResourceData) *nextgen.ClustersCluster {
 	}
 	return &clusterDetails
 }
+
+const argoK8sAuthApiVersion = "client.authentication.k8s.io/v1beta1"
+
+// buildAuthPresetExecProviderConfig generates the argocd-k8s-auth exec
+// provider config for a managed Kubernetes offering, so the agent obtains
+// short-lived credentials from the cloud provider's IAM.
+func buildAuthPresetExecProviderConfig(preset map[string]interface{}) *nextgen.ClustersExecProviderConfig {
+	execProviderConfig := &nextgen.ClustersExecProviderConfig{
+		Command:     "argocd-k8s-auth",
+		ApiVersion:  argoK8sAuthApiVersion,
+		InstallHint: "argocd-k8s-auth is bundled with the GitOps agent image",
+	}
+
+	switch preset["provider"].(string) {
+	case "EKS":
+		execProviderConfig.Args = []string{"aws", "--cluster-name", preset["aws_cluster_name"].(string)}
+		if roleArn := preset["aws_role_arn"].(string); roleArn != "" {
+			execProviderConfig.Args = append(execProviderConfig.Args, "--role-arn", roleArn)
+		}
+	case "GKE":
+		execProviderConfig.Args = []string{"gcp"}
+	case "AKS":
+		execProviderConfig.Args = []string{"azure"}
+		execProviderConfig.Env = map[string]string{
+			"AAD_LOGIN_METHOD": "workloadidentity",
+		}
+		if clientId := preset["azure_client_id"].(string); clientId != "" {
+			execProviderConfig.Env["AZURE_CLIENT_ID"] = clientId
+		}
+		if tenantId := preset["azure_tenant_id"].(string); tenantId != "" {
+			execProviderConfig.Env["AZURE_TENANT_ID"] = tenantId
+		}
+	}
+
+	return execProviderConfig
+}
+
+// flattenAuthPreset returns the preset an exec provider config was generated
+// from by buildAuthPresetExecProviderConfig, or nil if it was not.
+func flattenAuthPreset(execProviderConfig *nextgen.ClustersExecProviderConfig) map[string]interface{} {
+	if execProviderConfig == nil || execProviderConfig.Command != "argocd-k8s-auth" || len(execProviderConfig.Args) == 0 {
+		return nil
+	}
+
+	args := execProviderConfig.Args
+	preset := map[string]interface{}{}
+	switch {
+	case args[0] == "aws" && len(args) >= 3 && args[1] == "--cluster-name":
+		preset["provider"] = "EKS"
+		preset["aws_cluster_name"] = args[2]
+		if len(args) == 5 && args[3] == "--role-arn" {
+			preset["aws_role_arn"] = args[4]
+		} else if len(args) != 3 {
+			return nil
+		}
+	case args[0] == "gcp" && len(args) == 1:
+		preset["provider"] = "GKE"
+	case args[0] == "azure" && len(args) == 1:
+		preset["provider"] = "AKS"
+		preset["azure_client_id"] = execProviderConfig.Env["AZURE_CLIENT_ID"]
+		preset["azure_tenant_id"] = execProviderConfig.Env["AZURE_TENANT_ID"]
+	default:
+		return nil
+	}
+	return preset
+}
+
+// validateAuthPreset checks that the settings of an auth preset are the ones
+// of its provider, as the generated command line would be invalid otherwise.
+func validateAuthPreset(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	presets, ok := d.Get("request.0.cluster.0.config.0.auth_preset").([]interface{})
+	if !ok || len(presets) == 0 || presets[0] == nil {
+		return nil
+	}
+	preset := presets[0].(map[string]interface{})
+
+	provider := preset["provider"].(string)
+	for _, setting := range []struct{ key, provider string }{
+		{"aws_cluster_name", "EKS"},
+		{"aws_role_arn", "EKS"},
+		{"azure_client_id", "AKS"},
+		{"azure_tenant_id", "AKS"},
+	} {
+		if preset[setting.key].(string) != "" && setting.provider != provider {
+			return fmt.Errorf("auth_preset.%s can only be set for %s clusters", setting.key, setting.provider)
+		}
+	}
+	if provider == "EKS" && preset["aws_cluster_name"].(string) == "" {
+		return fmt.Errorf("auth_preset.aws_cluster_name is required for EKS clusters")
+	}
+	return nil
+}
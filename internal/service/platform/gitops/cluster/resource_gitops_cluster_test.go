@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -168,6 +169,46 @@ func TestAccResourceGitopsCluster(t *testing.T) {
 	})
 }
 
+func TestAccResourceGitopsClusterAuthPreset(t *testing.T) {
+	id := strings.ToLower(fmt.Sprintf("%s%s", t.Name(), utils.RandStringBytes(5)))
+	id = strings.ReplaceAll(id, "_", "")
+	name := id
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	clusterServer := os.Getenv("HARNESS_TEST_AWS_CLUSTER_SERVER")
+	roleARN := os.Getenv("HARNESS_TEST_AWS_CLUSTER_ROLE_ARN")
+	awsClusterName := os.Getenv("HARNESS_TEST_AWS_CLUSTER_NAME")
+	caData := os.Getenv("HARNESS_TEST_AWS_CLUSTER_CA_DATA")
+	agentId := os.Getenv("HARNESS_TEST_AWS_GITOPS_AGENT")
+	resourceName := "harness_platform_gitops_cluster.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccResourceGitopsClusterDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGitopsClusterAuthPresetEKS(id, accountId, name, agentId, id, clusterServer, roleARN, awsClusterName, caData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "request.0.cluster.0.config.0.auth_preset.0.provider", "EKS"),
+					resource.TestCheckResourceAttr(resourceName, "request.0.cluster.0.config.0.auth_preset.0.aws_cluster_name", awsClusterName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"request.0.upsert", "request.0.cluster.0.info", "request.0.cluster.0.config.0.bearer_token"},
+				ImportStateIdFunc:       acctest.GitopsAgentProjectLevelResourceImportStateIdFunc(resourceName),
+			},
+			{
+				Config:      testAccResourceGitopsClusterAuthPresetEKS(id, accountId, name, agentId, id, clusterServer, roleARN, "", caData),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("aws_cluster_name is required for EKS clusters"),
+			},
+		},
+	})
+}
+
 func testAccGetCluster(resourceName string, state *terraform.State) (*nextgen.Servicev1Cluster, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
 	c, ctx := acctest.TestAccGetPlatformClientWithContext()
@@ -343,3 +384,47 @@ func testAccResourceGitopsClusterProjectLevelIAM(id string, accountId string, na
 		}
 		`, id, accountId, name, agentId, clusterName, clusterServer, roleARN, awsClusterName, caData)
 }
+
+func testAccResourceGitopsClusterAuthPresetEKS(id string, accountId string, name string, agentId string, clusterName string, clusterServer string, roleARN string, awsClusterName string, caData string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[3]s"
+		}
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[3]s"
+			org_id = harness_platform_organization.test.id
+		}
+		resource "harness_platform_gitops_cluster" "test" {
+			identifier = "%[1]s"
+			account_id = "%[2]s"
+			agent_id = "%[4]s"
+			project_id = harness_platform_project.test.id
+			org_id = harness_platform_organization.test.id
+			request {
+				upsert = true
+				cluster {
+					server = "%[6]s"
+					name = "%[5]s"
+					config {
+						tls_client_config {
+							insecure = false
+							ca_data = "%[9]s"
+						}
+						auth_preset {
+							provider = "EKS"
+							aws_cluster_name = "%[8]s"
+							aws_role_arn = "%[7]s"
+						}
+					}
+				}
+			}
+			lifecycle {
+				ignore_changes = [
+					request.0.upsert, request.0.cluster.0.config.0.bearer_token, request.0.cluster.0.info,
+				]
+			}
+		}
+		`, id, accountId, name, agentId, clusterName, clusterServer, roleARN, awsClusterName, caData)
+}
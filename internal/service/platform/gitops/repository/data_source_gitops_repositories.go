@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceGitopsRepositoryList() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for listing the Harness GitOps Repositories registered on an agent.",
+
+		ReadContext: dataSourceGitOpsRepositoryListRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Description: "Account identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"org_id": {
+				Description: "Organization identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"agent_id": {
+				Description: "Agent identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"search_term": {
+				Description: "Only list repositories matching the search term.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"repositories": {
+				Description: "Repositories registered on the agent.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the GitOps repository.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"repo": {
+							Description: "URL to the remote repository.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type_": {
+							Description: "Type of the repo. Either \"git\" or \"helm\".",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the repo. Only used with Helm repos.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"project": {
+							Description: "Project of the repository in the agent.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"connection_type": {
+							Description: "Authentication method used to connect to the repository.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"repo_cred_id": {
+							Description: "Identifier of the repository credential template the repository inherits its credentials from.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"connection_state": connectionStateSchema(),
+					},
+				},
+			},
+		},
+	}
+	return resource
+}
+
+func dataSourceGitOpsRepositoryListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	registered, httpResp, err := listAgentRepositories(ctx, c, d, d.Get("search_term").(string))
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	repos := []interface{}{}
+	for _, repo := range registered {
+		if repo.Repository == nil {
+			continue
+		}
+		repos = append(repos, map[string]interface{}{
+			"identifier":       repo.Identifier,
+			"repo":             repo.Repository.Repo,
+			"type_":            repo.Repository.Type_,
+			"name":             repo.Repository.Name,
+			"project":          repo.Repository.Project,
+			"connection_type":  repo.Repository.ConnectionType,
+			"repo_cred_id":     repo.RepositoryCredentialsId,
+			"connection_state": flattenConnectionState(repo.Repository.ConnectionState),
+		})
+	}
+
+	d.SetId(d.Get("agent_id").(string))
+	d.Set("repositories", repos)
+	return nil
+}
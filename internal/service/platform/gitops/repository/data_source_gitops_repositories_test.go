@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGitopsRepositoryList(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	id = strings.ToLower(strings.ReplaceAll(id, "_", ""))
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	resourceName := "data.harness_platform_gitops_repositories.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGitopsRepositoryList(id, accountId, agentId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "repositories.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "repositories.0.identifier", id),
+					resource.TestCheckResourceAttrSet(resourceName, "repositories.0.connection_state.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGitopsRepositoryList(id string, accountId string, agentId string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_gitops_repository" "test" {
+			identifier = "%[1]s"
+			account_id = "%[2]s"
+			agent_id = "%[3]s"
+			repo {
+				repo = "https://github.com/willycoll/argocd-example-apps.git"
+				name = "%[1]s"
+				insecure = true
+				connection_type = "HTTPS_ANONYMOUS"
+			}
+			upsert = true
+		}
+
+		data "harness_platform_gitops_repositories" "test" {
+			account_id = "%[2]s"
+			agent_id = harness_platform_gitops_repository.test.agent_id
+			search_term = harness_platform_gitops_repository.test.identifier
+		}
+	`, id, accountId, agentId)
+}
@@ -178,6 +178,7 @@ func DataSourceGitopsRepository() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"connection_state": connectionStateSchema(),
 			"update_mask": {
 				Description: "Update mask of the repository.",
 				Type:        schema.TypeList,
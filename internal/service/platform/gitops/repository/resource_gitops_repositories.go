@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/antihax/optional"
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// repositoryListPageSize is the page size used when listing the repositories of an agent.
+const repositoryListPageSize = 100
+
+func ResourceGitopsRepositoryBatch() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for registering a set of Harness GitOps Repositories on an agent. Repositories without credentials of their own inherit them from a matching repository credential template. The API has no batch endpoint, so one request is made per repository created, updated or deleted, while reads list the repositories of the agent a page at a time.",
+
+		CreateContext: resourceGitOpsRepositoryBatchCreate,
+		ReadContext:   resourceGitOpsRepositoryBatchRead,
+		UpdateContext: resourceGitOpsRepositoryBatchUpdate,
+		DeleteContext: resourceGitOpsRepositoryBatchDelete,
+		Importer:      gitOpsRepositoryBatchImporter,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "Project identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"org_id": {
+				Description: "Organization identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"agent_id": {
+				Description: "Agent identifier of the GitOps repositories.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"repo_cred_id": {
+				Description: "Identifier of the GitOps repository credential template shared by the repositories. Every repository URL must start with the URL of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"connection_type": {
+				Description: "Identifies the authentication method used to connect to the repositories.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"upsert": {
+				Description: "Indicates if the GitOps repositories should be updated if existing and inserted if not.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"repositories": {
+				Description: "Repositories to register on the agent.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the GitOps repository.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"repo": {
+							Description: "URL to the remote repository.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"type_": {
+							Description: "Type specifies the type of the repo. Can be either \"git\" or \"helm\".",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "git",
+						},
+						"name": {
+							Description: "Name to be used for this repo. Only used with Helm repos.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"project": {
+							Description: "Reference between project and repository that allow you automatically to be added as item inside SourceRepos project entity.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"connection_status": {
+				Description: "Connection status of each repository, keyed by repository identifier.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+	return resource
+}
+
+// gitOpsRepositoryBatchImporter imports the repositories of an agent given
+// as a comma separated list of identifiers, in the format
+// <agent_id>/<identifiers> or <org_id>/<project_id>/<agent_id>/<identifiers>.
+var gitOpsRepositoryBatchImporter = &schema.ResourceImporter{
+	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		switch len(parts) {
+		case 2:
+		case 4:
+			d.Set("org_id", parts[0])
+			d.Set("project_id", parts[1])
+			parts = parts[2:]
+		default:
+			return nil, fmt.Errorf("invalid identifier: %s", d.Id())
+		}
+
+		var repos []interface{}
+		for _, identifier := range strings.Split(parts[1], ",") {
+			repos = append(repos, map[string]interface{}{
+				"identifier": identifier,
+			})
+		}
+		d.Set("agent_id", parts[0])
+		d.Set("repositories", repos)
+		d.SetId(parts[0])
+		return []*schema.ResourceData{d}, nil
+	},
+}
+
+func resourceGitOpsRepositoryBatchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	repos := d.Get("repositories").(*schema.Set).List()
+	if diags := validateRepoCredTemplate(ctx, c, d, repos); diags != nil {
+		return diags
+	}
+
+	// Only the repositories created so far are recorded if one fails, so
+	// the next apply creates the rest instead of replacing the whole set.
+	var created []interface{}
+	for _, repo := range repos {
+		if diags := createBatchRepository(ctx, c, d, repo.(map[string]interface{})); diags != nil {
+			if len(created) > 0 {
+				d.SetId(d.Get("agent_id").(string))
+				d.Set("repositories", created)
+			}
+			return diags
+		}
+		created = append(created, repo)
+	}
+	d.SetId(d.Get("agent_id").(string))
+	return resourceGitOpsRepositoryBatchRead(ctx, d, meta)
+}
+
+func resourceGitOpsRepositoryBatchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	registered, httpResp, err := listAgentRepositories(ctx, c, d, "")
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	byIdentifier := map[string]nextgen.Servicev1Repository{}
+	for _, repo := range registered {
+		byIdentifier[repo.Identifier] = repo
+	}
+
+	// Only repositories managed by this resource are tracked; repositories
+	// removed outside of Terraform drop out of state and are recreated.
+	var repos []interface{}
+	connectionStatus := map[string]interface{}{}
+	for _, r := range d.Get("repositories").(*schema.Set).List() {
+		managed := r.(map[string]interface{})
+		repo, ok := byIdentifier[managed["identifier"].(string)]
+		if !ok || repo.Repository == nil {
+			continue
+		}
+		repos = append(repos, map[string]interface{}{
+			"identifier": repo.Identifier,
+			"repo":       repo.Repository.Repo,
+			"type_":      repo.Repository.Type_,
+			"name":       repo.Repository.Name,
+			"project":    managed["project"],
+		})
+		// The connection type is shared by the repositories of the set.
+		if repo.Repository.ConnectionType != "" {
+			d.Set("connection_type", repo.Repository.ConnectionType)
+		}
+		if repo.Repository.ConnectionState != nil {
+			connectionStatus[repo.Identifier] = repo.Repository.ConnectionState.Status
+		}
+	}
+
+	d.Set("repositories", repos)
+	d.Set("connection_status", connectionStatus)
+	return nil
+}
+
+func resourceGitOpsRepositoryBatchUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	o, n := d.GetChange("repositories")
+	oldRepos := o.(*schema.Set)
+	newRepos := n.(*schema.Set)
+	if diags := validateRepoCredTemplate(ctx, c, d, newRepos.List()); diags != nil {
+		return diags
+	}
+
+	registered := map[string]bool{}
+	for _, repo := range oldRepos.List() {
+		registered[repo.(map[string]interface{})["identifier"].(string)] = true
+	}
+	kept := map[string]bool{}
+	for _, repo := range newRepos.List() {
+		kept[repo.(map[string]interface{})["identifier"].(string)] = true
+	}
+
+	for identifier := range registered {
+		if kept[identifier] {
+			continue
+		}
+		if diags := deleteBatchRepository(ctx, c, d, identifier); diags != nil {
+			return diags
+		}
+	}
+
+	// A changed repository shows up in the new set with a different hash, so
+	// only those, or all of them when the shared settings change, are sent.
+	for _, repo := range newRepos.List() {
+		if oldRepos.Contains(repo) && !d.HasChanges("connection_type", "repo_cred_id") {
+			continue
+		}
+		repo := repo.(map[string]interface{})
+		var diags diag.Diagnostics
+		if registered[repo["identifier"].(string)] {
+			diags = updateBatchRepository(ctx, c, d, repo)
+		} else {
+			diags = createBatchRepository(ctx, c, d, repo)
+		}
+		if diags != nil {
+			return diags
+		}
+	}
+	return resourceGitOpsRepositoryBatchRead(ctx, d, meta)
+}
+
+func resourceGitOpsRepositoryBatchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	ctx = context.WithValue(ctx, nextgen.ContextAccessToken, hh.EnvVars.BearerToken.Get())
+
+	for _, repo := range d.Get("repositories").(*schema.Set).List() {
+		if diags := deleteBatchRepository(ctx, c, d, repo.(map[string]interface{})["identifier"].(string)); diags != nil {
+			return diags
+		}
+	}
+	return nil
+}
+
+func updateBatchRepository(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, repo map[string]interface{}) diag.Diagnostics {
+	identifier := repo["identifier"].(string)
+	_, httpResp, err := c.RepositoriesApiService.AgentRepositoryServiceUpdateRepository(ctx, nextgen.RepositoriesRepoUpdateRequest{
+		Repo: buildBatchRepository(d, repo),
+	}, d.Get("agent_id").(string), identifier, &nextgen.RepositoriesApiAgentRepositoryServiceUpdateRepositoryOpts{
+		AccountIdentifier: optional.NewString(c.AccountId),
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+	})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	return nil
+}
+
+func createBatchRepository(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, repo map[string]interface{}) diag.Diagnostics {
+	_, httpResp, err := c.RepositoriesApiService.AgentRepositoryServiceCreateRepository(ctx, nextgen.RepositoriesRepoCreateRequest{
+		Upsert: d.Get("upsert").(bool),
+		Repo:   buildBatchRepository(d, repo),
+	}, d.Get("agent_id").(string), &nextgen.RepositoriesApiAgentRepositoryServiceCreateRepositoryOpts{
+		AccountIdentifier: optional.NewString(c.AccountId),
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+		Identifier:        optional.NewString(repo["identifier"].(string)),
+	})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	return nil
+}
+
+func deleteBatchRepository(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, identifier string) diag.Diagnostics {
+	_, httpResp, err := c.RepositoriesApiService.AgentRepositoryServiceDeleteRepository(ctx, d.Get("agent_id").(string), identifier, &nextgen.RepositoriesApiAgentRepositoryServiceDeleteRepositoryOpts{
+		AccountIdentifier: optional.NewString(c.AccountId),
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+	})
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	return nil
+}
+
+func buildBatchRepository(d *schema.ResourceData, repo map[string]interface{}) *nextgen.RepositoriesRepository {
+	return &nextgen.RepositoriesRepository{
+		Repo:           repo["repo"].(string),
+		Type_:          repo["type_"].(string),
+		Name:           repo["name"].(string),
+		Project:        repo["project"].(string),
+		ConnectionType: d.Get("connection_type").(string),
+		InheritedCreds: d.Get("repo_cred_id").(string) != "",
+	}
+}
+
+// validateRepoCredTemplate checks that every repository URL is covered by the
+// URL of the shared credential template, since the agent matches repository
+// credentials to repositories by URL prefix.
+func validateRepoCredTemplate(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, repos []interface{}) diag.Diagnostics {
+	repoCredId := d.Get("repo_cred_id").(string)
+	if repoCredId == "" {
+		return nil
+	}
+
+	creds, httpResp, err := c.RepositoryCredentialsApi.AgentRepositoryCredentialsServiceGetRepositoryCredentials(ctx, d.Get("agent_id").(string), repoCredId, c.AccountId, &nextgen.RepositoryCredentialsApiAgentRepositoryCredentialsServiceGetRepositoryCredentialsOpts{
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+	})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if creds.RepoCreds == nil {
+		return diag.Errorf("repository credential template %s not found", repoCredId)
+	}
+
+	for _, repo := range repos {
+		url := repo.(map[string]interface{})["repo"].(string)
+		if !strings.HasPrefix(url, creds.RepoCreds.Url) {
+			return diag.FromErr(fmt.Errorf("repository %s is not covered by the URL %s of repository credential template %s", url, creds.RepoCreds.Url, repoCredId))
+		}
+	}
+	return nil
+}
+
+// listAgentRepositories fetches every repository registered on the agent in
+// pages, so reconciling a large set costs one request per page rather than
+// one request per repository.
+func listAgentRepositories(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, searchTerm string) ([]nextgen.Servicev1Repository, *http.Response, error) {
+	var repos []nextgen.Servicev1Repository
+	query := nextgen.V1RepositoryQuery{
+		AccountIdentifier: c.AccountId,
+		OrgIdentifier:     d.Get("org_id").(string),
+		ProjectIdentifier: d.Get("project_id").(string),
+		AgentIdentifier:   d.Get("agent_id").(string),
+		SearchTerm:        searchTerm,
+		PageSize:          repositoryListPageSize,
+	}
+	for {
+		resp, httpResp, err := c.RepositoriesApiService.RepositoryServiceListRepositories(ctx, query)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		repos = append(repos, resp.Content...)
+		query.PageIndex++
+		if query.PageIndex >= resp.TotalPages || len(resp.Content) == 0 {
+			return repos, httpResp, nil
+		}
+	}
+}
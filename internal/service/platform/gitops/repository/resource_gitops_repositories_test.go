@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGitopsRepositoryBatch(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	id = strings.ToLower(strings.ReplaceAll(id, "_", ""))
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	resourceName := "harness_platform_gitops_repositories.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGitopsRepositoryBatch(id, agentId, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", agentId),
+					resource.TestCheckResourceAttr(resourceName, "repositories.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, fmt.Sprintf("connection_status.%sa", id)),
+				),
+			},
+			{
+				Config: testAccResourceGitopsRepositoryBatch(id, agentId, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "repositories.#", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s/%sa", agentId, id),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"upsert"},
+			},
+		},
+	})
+}
+
+func testAccResourceGitopsRepositoryBatch(id string, agentId string, both bool) string {
+	second := ""
+	if both {
+		second = fmt.Sprintf(`
+			repositories {
+				identifier = "%[1]sb"
+				repo = "https://github.com/argoproj/argocd-example-apps.git"
+			}`, id)
+	}
+	return fmt.Sprintf(`
+		resource "harness_platform_gitops_repositories" "test" {
+			agent_id = "%[2]s"
+			connection_type = "HTTPS_ANONYMOUS"
+			upsert = true
+			repositories {
+				identifier = "%[1]sa"
+				repo = "https://github.com/willycoll/argocd-example-apps.git"
+			}%[3]s
+		}
+	`, id, agentId, second)
+}
@@ -182,6 +182,12 @@ func ResourceGitopsRepositories() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"fail_on_connection_error": {
+				Description: "Validate that the agent can connect to the repository after create and update, and fail the apply if it cannot.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"connection_state": connectionStateSchema(),
 			"update_mask": {
 				Description: "Update mask of the repository.",
 				Type:        schema.TypeList,
@@ -241,7 +247,7 @@ func resourceGitOpsRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 		return nil
 	}
 	setRepositoryDetails(d, &resp)
-	return nil
+	return validateRepositoryConnection(ctx, c, d)
 }
 
 func resourceGitOpsRepositoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -325,7 +331,7 @@ func resourceGitOpsRepositoryUpdate(ctx context.Context, d *schema.ResourceData,
 		return nil
 	}
 	setRepositoryDetails(d, &resp)
-	return nil
+	return validateRepositoryConnection(ctx, c, d)
 }
 
 func resourceGitOpsRepositoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -369,6 +375,45 @@ func resourceGitOpsRepositoryDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// validateRepositoryConnection checks, when fail_on_connection_error is set,
+// that the agent can reach the repository with the configured credentials.
+func validateRepositoryConnection(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData) diag.Diagnostics {
+	if !d.Get("fail_on_connection_error").(bool) {
+		return nil
+	}
+	repo := buildRepo(d)
+	state, httpResp, err := c.RepositoriesApiService.AgentRepositoryServiceValidateAccess(ctx, nextgen.RepositoriesRepoAccessQuery{
+		Repo:                       repo.Repo,
+		Username:                   repo.Username,
+		Password:                   repo.Password,
+		SshPrivateKey:              repo.SshPrivateKey,
+		Insecure:                   repo.Insecure,
+		TlsClientCertData:          repo.TlsClientCertData,
+		TlsClientCertKey:           repo.TlsClientCertKey,
+		Type_:                      repo.Type_,
+		Name:                       repo.Name,
+		EnableOci:                  repo.EnableOCI,
+		GithubAppPrivateKey:        repo.GithubAppPrivateKey,
+		GithubAppID:                repo.GithubAppID,
+		GithubAppInstallationID:    repo.GithubAppInstallationID,
+		GithubAppEnterpriseBaseUrl: repo.GithubAppEnterpriseBaseUrl,
+		Proxy:                      repo.Proxy,
+		Project:                    repo.Project,
+	}, c.AccountId, d.Get("agent_id").(string), &nextgen.RepositoriesApiAgentRepositoryServiceValidateAccessOpts{
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+		Identifier:        optional.NewString(d.Get("identifier").(string)),
+	})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	d.Set("connection_state", flattenConnectionState(&state))
+	if state.Status != connectionStatusSuccessful {
+		return diag.Errorf("agent %s cannot connect to repository %s: %s %s", d.Get("agent_id").(string), repo.Repo, state.Status, state.Message)
+	}
+	return nil
+}
+
 func buildUpdateRepoRequest(d *schema.ResourceData) nextgen.RepositoriesRepoUpdateRequest {
 	var updateMask map[string]interface{}
 	if attr, ok := d.GetOk("update_mask"); ok {
@@ -508,5 +553,70 @@ func setRepositoryDetails(d *schema.ResourceData, repo *nextgen.Servicev1Reposit
 
 		repoList = append(repoList, repoO)
 		d.Set("repo", repoList)
+		d.Set("connection_state", flattenConnectionState(repo.Repository.ConnectionState))
+	}
+}
+
+// connectionStatusSuccessful is the connection status reported by the agent
+// when it could access the repository.
+const connectionStatusSuccessful = "Successful"
+
+func connectionStateSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Information about the connection to the repository.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"status": {
+					Description: "Current status indicator of the connection.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"message": {
+					Description: "Information about the connection status.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"attempted_at": {
+					Description: "Time when the connection was last attempted.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"seconds": {
+								Description: "Represents seconds of UTC time since Unix epoch 1970-01-01T00:00:00Z.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"nanos": {
+								Description: "Non-negative fractions of a second at nanosecond resolution.",
+								Type:        schema.TypeInt,
+								Computed:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenConnectionState(state *nextgen.CommonsConnectionState) []interface{} {
+	if state == nil {
+		return nil
+	}
+	connectionState := map[string]interface{}{
+		"status":  state.Status,
+		"message": state.Message,
+	}
+	if state.AttemptedAt != nil {
+		connectionState["attempted_at"] = []interface{}{
+			map[string]interface{}{
+				"seconds": state.AttemptedAt.Seconds,
+				"nanos":   int(state.AttemptedAt.Nanos),
+			},
+		}
 	}
+	return []interface{}{connectionState}
 }
@@ -3,6 +3,7 @@ package repository_test
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -49,7 +50,7 @@ func TestAccResourceGitopsRepository(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"upsert", "update_mask", "repo.0.type_"},
+				ImportStateVerifyIgnore: []string{"upsert", "update_mask", "repo.0.type_", "connection_state"},
 				ImportStateIdFunc:       acctest.GitopsAgentProjectLevelResourceImportStateIdFunc(resourceName),
 			},
 		},
@@ -87,13 +88,38 @@ func TestAccResourceGitopsRepository(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"upsert", "update_mask", "repo.0.type_"},
+				ImportStateVerifyIgnore: []string{"upsert", "update_mask", "repo.0.type_", "connection_state"},
 				ImportStateIdFunc:       acctest.GitopsAgentAccountLevelResourceImportStateIdFunc(resourceName),
 			},
 		},
 	})
 }
 
+func TestAccResourceGitopsRepositoryFailOnConnectionError(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	id = strings.ReplaceAll(id, "_", "")
+	agentId := os.Getenv("HARNESS_TEST_GITOPS_AGENT_ID")
+	resourceName := "harness_platform_gitops_repository.test"
+	accountId := os.Getenv("HARNESS_ACCOUNT_ID")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceGitopsRepositoryFailOnConnectionError(id, "https://github.com/willycoll/does-not-exist.git", agentId, accountId),
+				ExpectError: regexp.MustCompile("cannot connect to repository"),
+			},
+			{
+				Config: testAccResourceGitopsRepositoryFailOnConnectionError(id, "https://github.com/willycoll/argocd-example-apps.git", agentId, accountId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "connection_state.0.status", "Successful"),
+				),
+			},
+		},
+	})
+}
+
 func testAccGetRepository(resourceName string, state *terraform.State) (*nextgen.Servicev1Repository, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
 	c, ctx := acctest.TestAccGetPlatformClientWithContext()
@@ -176,3 +202,20 @@ func testAccResourceGitopsRepositoryAccountLevel(id string, name string, repo st
 		}
 	`, id, name, repo, repoName, agentId, accountId)
 }
+
+func testAccResourceGitopsRepositoryFailOnConnectionError(id string, repo string, agentId string, accountId string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_gitops_repository" "test" {
+			identifier = "%[1]s"
+			account_id = "%[4]s"
+			agent_id = "%[3]s"
+			repo {
+				repo = "%[2]s"
+				name = "%[1]s"
+				connection_type = "HTTPS_ANONYMOUS"
+			}
+			upsert = true
+			fail_on_connection_error = true
+		}
+	`, id, repo, agentId, accountId)
+}
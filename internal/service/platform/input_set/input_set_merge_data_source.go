@@ -0,0 +1,132 @@
+package input_set
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceInputSetMerge() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for previewing the runtime inputs of a pipeline once a list of input sets is merged.",
+
+		ReadContext: dataSourceInputSetMergeRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id":     helpers.GetOrgIdSchema(helpers.SchemaFlagTypes.Required),
+			"project_id": helpers.GetProjectIdSchema(helpers.SchemaFlagTypes.Required),
+			"pipeline_id": {
+				Description: "Identifier of the pipeline",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"input_set_ids": {
+				Description: "Identifiers of the input sets or overlay input sets to merge, in order. Values from later input sets override those of earlier ones.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"stage_ids": {
+				Description: "Identifiers of the stages to merge the inputs of. Defaults to all stages.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"branch": {
+				Description: "Branch of the pipeline and input sets, for pipelines stored in Git.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"yaml": {
+				Description: "Merged runtime inputs YAML.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"pipeline_yaml": {
+				Description: "Pipeline YAML with the merged runtime inputs applied.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"unresolved_inputs": {
+				Description: "Paths of the fields still set to `<+input>` after merging, as in `pipeline.stages.build.spec.execution.steps.hello.timeout`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceInputSetMergeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	request := nextgen.MergeInputSetRequest{
+		InputSetReferences:     helpers.ExpandField(d.Get("input_set_ids").([]interface{})),
+		StageIdentifiers:       helpers.ExpandField(d.Get("stage_ids").([]interface{})),
+		WithMergedPipelineYaml: true,
+	}
+
+	resp, httpResp, err := c.InputSetsApi.MergeInputSets(ctx, request, c.AccountId, orgId, projectId, pipelineId, &nextgen.InputSetsApiMergeInputSetsOpts{
+		PipelineBranch: helpers.BuildField(d, "branch"),
+		Branch:         helpers.BuildField(d, "branch"),
+	})
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	if resp.Data == nil {
+		return diag.Errorf("input sets %s of pipeline %s were not merged", strings.Join(request.InputSetReferences, ", "), pipelineId)
+	}
+
+	merged := resp.Data
+	if merged.IsErrorResponse {
+		return diag.Errorf("input sets %s of pipeline %s could not be merged: %s", strings.Join(request.InputSetReferences, ", "), pipelineId, mergeErrors(merged.InputSetErrorWrapper))
+	}
+
+	var unresolved []string
+	if merged.PipelineYaml != "" {
+		if unresolved, err = helpers.RuntimeInputPaths(merged.PipelineYaml); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join([]string{orgId, projectId, pipelineId, merged.PipelineYaml}, "/")))))
+	d.Set("yaml", merged.PipelineYaml)
+	d.Set("pipeline_yaml", merged.CompletePipelineYaml)
+	d.Set("unresolved_inputs", unresolved)
+
+	return nil
+}
+
+// mergeErrors lists the paths of the fields that failed to merge.
+func mergeErrors(wrapper *nextgen.InputSetErrorWrapper) string {
+	if wrapper == nil || len(wrapper.UuidToErrorResponseMap) == 0 {
+		return "no details returned"
+	}
+	var fields []string
+	for fqn := range wrapper.UuidToErrorResponseMap {
+		fields = append(fields, fqn)
+	}
+	sort.Strings(fields)
+	return "invalid fields " + strings.Join(fields, ", ")
+}
@@ -0,0 +1,52 @@
+package input_set_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceInputSetMerge(t *testing.T) {
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "data.harness_platform_input_set_merge.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceInputSetMerge(id, name, `[harness_platform_input_set.one.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "yaml", regexp.MustCompile(`value: "?hello"?`)),
+					resource.TestCheckResourceAttr(resourceName, "unresolved_inputs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "unresolved_inputs.0", "pipeline.variables.second.value"),
+				),
+			},
+			{
+				Config: testAccDataSourceInputSetMerge(id, name, `[harness_platform_input_set.one.id, harness_platform_input_set.two.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "yaml", regexp.MustCompile(`value: "?world"?`)),
+					resource.TestCheckResourceAttr(resourceName, "unresolved_inputs.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceInputSetMerge(id string, name string, inputSetIds string) string {
+	return fmt.Sprintf(`
+	%[1]s
+
+	data "harness_platform_input_set_merge" "test" {
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		pipeline_id = harness_platform_pipeline.test.id
+		input_set_ids = %[2]s
+	}
+	`, testAccInputSetsOfPipeline(id, name), inputSetIds)
+}
@@ -2,6 +2,7 @@ package input_set
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/antihax/optional"
@@ -21,6 +22,7 @@ func ResourceInputSet() *schema.Resource {
 		UpdateContext: resourceInputSetCreateOrUpdate,
 		CreateContext: resourceInputSetCreateOrUpdate,
 		DeleteContext: resourceInputSetDelete,
+		CustomizeDiff: resourceInputSetCustomizeDiff,
 		Importer:      helpers.PipelineResourceImporter,
 
 		Schema: map[string]*schema.Schema{
@@ -342,3 +344,14 @@ func readGitDetails(inputSet *nextgen.InputSetResponseBody, store_type optional.
 	}
 	return git_details
 }
+
+// resourceInputSetCustomizeDiff rejects broken input set YAML during plan.
+func resourceInputSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("yaml") {
+		return nil
+	}
+	if err := helpers.ValidateEntityYaml(d.Get("yaml").(string), "inputSet"); err != nil {
+		return fmt.Errorf("invalid input set YAML: %w", err)
+	}
+	return nil
+}
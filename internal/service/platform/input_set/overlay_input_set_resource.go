@@ -0,0 +1,210 @@
+package input_set
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func ResourceOverlayInputSet() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for creating a Harness Overlay Input Set, an ordered list of input sets applied on top of each other.",
+
+		ReadContext:   resourceOverlayInputSetRead,
+		UpdateContext: resourceOverlayInputSetCreateOrUpdate,
+		CreateContext: resourceOverlayInputSetCreateOrUpdate,
+		DeleteContext: resourceOverlayInputSetDelete,
+		Importer:      helpers.PipelineResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Description: "Identifier of the pipeline",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"input_set_ids": {
+				Description: "Identifiers of the input sets to apply, in order. Values from later input sets override those of earlier ones.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"yaml": {
+				Description: "Overlay Input Set YAML as stored in Harness.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+	helpers.SetProjectLevelResourceSchema(resource.Schema)
+
+	return resource
+}
+
+func resourceOverlayInputSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	resp, httpResp, err := c.InputSetsApi.GetOverlayInputSet(ctx, d.Id(), c.AccountId, orgId, projectId, pipelineId, &nextgen.InputSetsApiGetOverlayInputSetOpts{})
+
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	if resp.Data == nil {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	readOverlayInputSet(d, resp.Data)
+
+	if len(resp.Data.InvalidInputSetReferences) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Overlay input set %s has invalid input set references", resp.Data.Identifier),
+			Detail:   invalidInputSetReferences(resp.Data.InvalidInputSetReferences),
+		}}
+	}
+
+	return nil
+}
+
+func resourceOverlayInputSetCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	var err error
+	var resp nextgen.ResponseDtoOverlayInputSetResponse
+	var httpResp *http.Response
+
+	id := d.Id()
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	pipelineIdentifier := d.Get("pipeline_id").(string)
+
+	body, err := buildOverlayInputSetYaml(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if id == "" {
+		resp, httpResp, err = c.InputSetsApi.PostOverlayInputSet(ctx, body, c.AccountId, orgIdentifier, projectIdentifier, pipelineIdentifier, &nextgen.InputSetsApiPostOverlayInputSetOpts{})
+	} else {
+		resp, httpResp, err = c.InputSetsApi.PutOverlayInputSet(ctx, body, c.AccountId, orgIdentifier, projectIdentifier, pipelineIdentifier, id, &nextgen.InputSetsApiPutOverlayInputSetOpts{})
+	}
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	if resp.Data == nil {
+		return diag.Errorf("overlay input set %s was not saved", d.Get("identifier").(string))
+	}
+
+	if resp.Data.IsErrorResponse {
+		return diag.Errorf("overlay input set %s could not be saved: %s", resp.Data.Identifier, invalidInputSetReferences(resp.Data.InvalidInputSetReferences))
+	}
+
+	readOverlayInputSet(d, resp.Data)
+
+	return nil
+}
+
+func resourceOverlayInputSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	pipelineIdentifier := d.Get("pipeline_id").(string)
+
+	_, httpResp, err := c.InputSetsApi.DeleteInputSet(ctx, d.Id(), c.AccountId, orgIdentifier, projectIdentifier, pipelineIdentifier, &nextgen.InputSetsApiDeleteInputSetOpts{})
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return nil
+}
+
+type overlayInputSetDocument struct {
+	OverlayInputSet overlayInputSet `yaml:"overlayInputSet"`
+}
+
+type overlayInputSet struct {
+	Name               string            `yaml:"name"`
+	Identifier         string            `yaml:"identifier"`
+	Description        string            `yaml:"description,omitempty"`
+	OrgIdentifier      string            `yaml:"orgIdentifier"`
+	ProjectIdentifier  string            `yaml:"projectIdentifier"`
+	PipelineIdentifier string            `yaml:"pipelineIdentifier"`
+	Tags               map[string]string `yaml:"tags"`
+	InputSetReferences []string          `yaml:"inputSetReferences"`
+}
+
+// buildOverlayInputSetYaml renders the overlay input set, which the API only
+// accepts as YAML.
+func buildOverlayInputSetYaml(d *schema.ResourceData) (string, error) {
+	doc := overlayInputSetDocument{
+		OverlayInputSet: overlayInputSet{
+			Name:               d.Get("name").(string),
+			Identifier:         d.Get("identifier").(string),
+			Description:        d.Get("description").(string),
+			OrgIdentifier:      d.Get("org_id").(string),
+			ProjectIdentifier:  d.Get("project_id").(string),
+			PipelineIdentifier: d.Get("pipeline_id").(string),
+			Tags:               helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+			InputSetReferences: helpers.ExpandField(d.Get("input_set_ids").([]interface{})),
+		},
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func readOverlayInputSet(d *schema.ResourceData, inputSet *nextgen.OverlayInputSetResponse) {
+	d.SetId(inputSet.Identifier)
+	d.Set("identifier", inputSet.Identifier)
+	d.Set("name", inputSet.Name)
+	d.Set("description", inputSet.Description)
+	d.Set("tags", helpers.FlattenTags(inputSet.Tags))
+	d.Set("org_id", inputSet.OrgIdentifier)
+	d.Set("project_id", inputSet.ProjectIdentifier)
+	d.Set("pipeline_id", inputSet.PipelineIdentifier)
+	d.Set("input_set_ids", inputSet.InputSetReferences)
+	d.Set("yaml", inputSet.OverlayInputSetYaml)
+}
+
+// invalidInputSetReferences describes the references the API rejected, one
+// per line, in a stable order.
+func invalidInputSetReferences(references map[string]string) string {
+	var lines []string
+	for ref, reason := range references {
+		lines = append(lines, fmt.Sprintf("%s: %s", ref, reason))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,195 @@
+package input_set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceOverlayInputSet(t *testing.T) {
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	updatedName := fmt.Sprintf("%s_updated", name)
+	resourceName := "harness_platform_overlay_input_set.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccOverlayInputSetDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOverlayInputSet(id, name, `[harness_platform_input_set.one.id, harness_platform_input_set.two.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_id", id),
+					resource.TestCheckResourceAttr(resourceName, "input_set_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_ids.0", "one"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_ids.1", "two"),
+				),
+			},
+			{
+				Config: testAccResourceOverlayInputSet(id, updatedName, `[harness_platform_input_set.two.id, harness_platform_input_set.one.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(resourceName, "input_set_ids.0", "two"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_ids.1", "one"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.PipelineResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccOverlayInputSetDestroy(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		inputSet, _ := testAccGetOverlayInputSet(resourceName, state)
+		if inputSet != nil {
+			return fmt.Errorf("Found overlay input set: %s", inputSet.Identifier)
+		}
+		return nil
+	}
+}
+
+func testAccGetOverlayInputSet(resourceName string, state *terraform.State) (*nextgen.OverlayInputSetResponse, error) {
+	r := acctest.TestAccGetResource(resourceName, state)
+	c, ctx := acctest.TestAccGetPlatformClientWithContext()
+	orgIdentifier := r.Primary.Attributes["org_id"]
+	projectIdentifier := r.Primary.Attributes["project_id"]
+	pipelineIdentifier := r.Primary.Attributes["pipeline_id"]
+
+	resp, _, err := c.InputSetsApi.GetOverlayInputSet(ctx, r.Primary.ID, c.AccountId, orgIdentifier, projectIdentifier, pipelineIdentifier, &nextgen.InputSetsApiGetOverlayInputSetOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+func testAccResourceOverlayInputSet(id string, name string, inputSetIds string) string {
+	return fmt.Sprintf(`
+	%[1]s
+
+	resource "harness_platform_overlay_input_set" "test" {
+		identifier = "%[2]s"
+		name = "%[3]s"
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		pipeline_id = harness_platform_pipeline.test.id
+		input_set_ids = %[4]s
+	}
+	`, testAccInputSetsOfPipeline(id, name), id, name, inputSetIds)
+}
+
+// testAccInputSetsOfPipeline is a pipeline with two runtime inputs, and the
+// input sets `one` and `two` that each supply one of them.
+func testAccInputSetsOfPipeline(id string, name string) string {
+	return fmt.Sprintf(`
+	resource "harness_platform_organization" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+	}
+
+	resource "harness_platform_project" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_organization.test.id
+		color = "#472848"
+	}
+
+	resource "harness_platform_pipeline" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		yaml = <<-EOT
+		pipeline:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      stages:
+        - stage:
+            name: greet
+            identifier: greet
+            type: Custom
+            spec:
+              execution:
+                steps:
+                  - step:
+                      name: hello
+                      identifier: hello
+                      type: ShellScript
+                      timeout: 10m
+                      spec:
+                        shell: Bash
+                        onDelegate: true
+                        source:
+                          type: Inline
+                          spec:
+                            script: echo <+pipeline.variables.first> <+pipeline.variables.second>
+      variables:
+        - name: first
+          type: String
+          value: <+input>
+        - name: second
+          type: String
+          value: <+input>
+      EOT
+	}
+
+	resource "harness_platform_input_set" "one" {
+		identifier = "one"
+		name = "one"
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		pipeline_id = harness_platform_pipeline.test.id
+		yaml = <<-EOT
+		inputSet:
+      identifier: one
+      name: one
+      orgIdentifier: ${harness_platform_pipeline.test.org_id}
+      projectIdentifier: ${harness_platform_pipeline.test.project_id}
+      pipeline:
+        identifier: ${harness_platform_pipeline.test.id}
+        variables:
+          - name: first
+            type: String
+            value: hello
+      EOT
+	}
+
+	resource "harness_platform_input_set" "two" {
+		identifier = "two"
+		name = "two"
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		pipeline_id = harness_platform_pipeline.test.id
+		yaml = <<-EOT
+		inputSet:
+      identifier: two
+      name: two
+      orgIdentifier: ${harness_platform_pipeline.test.org_id}
+      projectIdentifier: ${harness_platform_pipeline.test.project_id}
+      pipeline:
+        identifier: ${harness_platform_pipeline.test.id}
+        variables:
+          - name: second
+            type: String
+            value: world
+      EOT
+	}
+	`, id, name)
+}
@@ -111,10 +111,12 @@ func dataSourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta in
 	project_id := d.Get("project_id").(string)
 	pipeline_id := d.Get("identifier").(string)
 	template_applied := d.Get("template_applied").(bool)
-	store_type := helpers.BuildField(d, "git_details.0.store_type")
-	base_branch := helpers.BuildField(d, "git_details.0.base_branch")
-	commit_message := helpers.BuildField(d, "git_details.0.commit_message")
-	connector_ref := helpers.BuildField(d, "git_details.0.connector_ref")
+	git_config := pipelineGitConfig{
+		StoreType:     helpers.BuildField(d, "git_details.0.store_type"),
+		BaseBranch:    helpers.BuildField(d, "git_details.0.base_branch"),
+		CommitMessage: helpers.BuildField(d, "git_details.0.commit_message"),
+		ConnectorRef:  helpers.BuildField(d, "git_details.0.connector_ref"),
+	}
 
 	resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx,
 		org_id,
@@ -127,7 +129,7 @@ func dataSourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta in
 		return helpers.HandleApiError(err, d, httpResp)
 	}
 
-	readPipeline(d, resp, org_id, project_id, template_applied, store_type, base_branch, commit_message, connector_ref)
+	readPipeline(d, resp, org_id, project_id, template_applied, git_config)
 
 	return nil
 }
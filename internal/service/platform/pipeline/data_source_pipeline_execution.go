@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourcePipelineExecution() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving an execution of a Harness pipeline. Reads the latest execution unless `execution_id` is set.",
+
+		ReadContext: dataSourcePipelineExecutionRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Description: "Organization identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"pipeline_id": {
+				Description: "Identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"module": {
+				Description: "Module the pipeline is run in, such as `cd` or `ci`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "cd",
+			},
+			"execution_id": {
+				Description: "Identifier of the execution. Defaults to the latest execution of the pipeline.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"outputs": {
+				Description: "Output variables published by the steps of the execution, keyed by `<step identifier>.<variable>`.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"execution_url": {
+				Description: "Link to the execution in the Harness UI.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+	return resource
+}
+
+func dataSourcePipelineExecutionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	session := meta.(*internal.Session)
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	executionId := d.Get("execution_id").(string)
+	if executionId == "" {
+		resp, httpResp, err := c.ExecutionDetailsApi.GetListOfExecutions(ctx, c.AccountId, orgId, projectId, &nextgen.ExecutionDetailsApiGetListOfExecutionsOpts{
+			Body:               optional.NewInterface(map[string]string{"filterType": "PipelineExecution"}),
+			PipelineIdentifier: optional.NewString(pipelineId),
+			Page:               optional.NewInt32(0),
+			Size:               optional.NewInt32(1),
+			Sort:               optional.NewInterface([]string{"startTs,DESC"}),
+		})
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		if resp.Data == nil || len(resp.Data.Content) == 0 {
+			return diag.Errorf("pipeline %s has no executions", pipelineId)
+		}
+		executionId = resp.Data.Content[0].PlanExecutionId
+	}
+
+	detail, httpResp, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, orgId, projectId, executionId, &nextgen.ExecutionDetailsApiGetExecutionDetailV2Opts{
+		RenderFullBottomGraph: optional.NewBool(true),
+	})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if detail.Data == nil || detail.Data.PipelineExecutionSummary == nil {
+		return diag.Errorf("execution %s of pipeline %s not found", executionId, pipelineId)
+	}
+
+	d.SetId(executionId)
+	setPipelineExecutionDetail(d, detail.Data)
+	d.Set("execution_url", executionUrl(session, d.Get("module").(string), orgId, projectId, pipelineId, executionId))
+	return nil
+}
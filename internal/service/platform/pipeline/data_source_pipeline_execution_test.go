@@ -0,0 +1,42 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePipelineExecution(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resourceName := "data.harness_platform_pipeline_execution.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePipelineExecution(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "execution_id", "harness_platform_pipeline_execution.test", "execution_id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.echo.greeting", "hello"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePipelineExecution(id string, name string) string {
+	return testAccResourcePipelineExecution(id, name, "1") + `
+		data "harness_platform_pipeline_execution" "test" {
+			org_id = harness_platform_pipeline_execution.test.org_id
+			project_id = harness_platform_pipeline_execution.test.project_id
+			pipeline_id = harness_platform_pipeline_execution.test.pipeline_id
+		}
+	`
+}
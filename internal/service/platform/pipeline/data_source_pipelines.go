@@ -0,0 +1,319 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const pipelineListPageSize = 100
+
+func DataSourcePipelines() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for listing the pipelines of a project.",
+
+		ReadContext: dataSourcePipelinesRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id":     helpers.GetOrgIdSchema(helpers.SchemaFlagTypes.Required),
+			"project_id": helpers.GetProjectIdSchema(helpers.SchemaFlagTypes.Required),
+			"search_term": {
+				Description: "Only return pipelines whose name, identifier or tags contain this value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Only return pipelines carrying all of these tags. Tags are given as `name` or `name:value`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"module": {
+				Description:  "Only return pipelines with stages of this module. Possible values: CD, CI, STO.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"CD", "CI", "STO"}, true),
+			},
+			"template_ref": {
+				Description: "Only return pipelines using this template, referred to as in the pipeline YAML, e.g. `account.my_template` for an account level template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"store_type": {
+				Description:  "Only return pipelines stored this way. Possible values: INLINE, REMOTE.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"INLINE", "REMOTE"}, false),
+			},
+			"filter_id": {
+				Description: "Identifier of a saved pipeline filter, as created with `harness_platform_pipeline_filters`, to apply.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"pipelines": {
+				Description: "The matching pipelines.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "Description of the pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "Tags of the pipeline, as `name:value` strings.",
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"modules": {
+							Description: "Modules of the stages of the pipeline.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"store_type": {
+							Description: "Whether the pipeline is stored in Harness (INLINE) or in Git (REMOTE).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"connector_ref": {
+							Description: "Identifier of the Git connector of a remote pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"git_details": {
+							Description: "Git details of a remote pipeline.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"branch_name": {
+										Description: "Name of the branch.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"file_path": {
+										Description: "File path of the pipeline in the repository.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"repo_name": {
+										Description: "Name of the repository.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"file_url": {
+										Description: "URL of the pipeline file.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"last_commit_id": {
+										Description: "Last commit identifier of the pipeline file.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"last_object_id": {
+										Description: "Last object identifier of the pipeline file.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"last_execution_id": {
+							Description: "Identifier of the last execution of the pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_execution_status": {
+							Description: "Status of the last execution of the pipeline.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_execution_started_at": {
+							Description: "Start time of the last execution of the pipeline in milliseconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"updated_at": {
+							Description: "Last modification time of the pipeline in milliseconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourcePipelinesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+
+	opts := &nextgen.PipelinesApiListPipelinesOpts{
+		HarnessAccount:   optional.NewString(c.AccountId),
+		Limit:            optional.NewInt32(pipelineListPageSize),
+		SearchTerm:       helpers.BuildField(d, "search_term"),
+		FilterIdentifier: helpers.BuildField(d, "filter_id"),
+	}
+	if module, ok := d.GetOk("module"); ok {
+		opts.Module = optional.NewString(strings.ToLower(module.(string)))
+	}
+	var list []nextgen.PipelineListResponseBody
+	for page := int32(0); ; page++ {
+		opts.Page = optional.NewInt32(page)
+		resp, httpResp, err := c.PipelinesApi.ListPipelines(ctx, orgId, projectId, opts)
+
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+
+		list = append(list, resp...)
+		if len(resp) < pipelineListPageSize {
+			break
+		}
+	}
+
+	// Tags are matched here rather than sent: the client does not repeat
+	// multi-valued query parameters, and a single joined value is not known
+	// to be split by the server.
+	tags := helpers.ExpandField(d.Get("tags").(*schema.Set).List())
+	storeType := d.Get("store_type").(string)
+	templateRef := d.Get("template_ref").(string)
+
+	pipelines := []interface{}{}
+	for i := range list {
+		pipeline := &list[i]
+		if !hasAllTags(pipeline.Tags, tags) {
+			continue
+		}
+		if storeType != "" && pipelineStoreType(pipeline) != storeType {
+			continue
+		}
+		if templateRef != "" {
+			// The list does not include the YAML, so it has to be fetched
+			// for each pipeline left, from its own branch when it is remote.
+			getOpts := &nextgen.PipelinesApiGetPipelineOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+			}
+			if pipeline.GitDetails != nil && pipeline.GitDetails.BranchName != "" {
+				getOpts.BranchName = optional.NewString(pipeline.GitDetails.BranchName)
+			}
+			resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx, orgId, projectId, pipeline.Identifier, getOpts)
+			if err != nil {
+				return helpers.HandleApiError(err, d, httpResp)
+			}
+			uses, err := usesTemplate(resp.PipelineYaml, templateRef)
+			if err != nil {
+				return diag.Errorf("invalid YAML of pipeline %s: %s", pipeline.Identifier, err)
+			}
+			if !uses {
+				continue
+			}
+		}
+
+		pipelines = append(pipelines, flattenPipelineSummary(pipeline))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", orgId, projectId))
+	d.Set("pipelines", pipelines)
+
+	return nil
+}
+
+func flattenPipelineSummary(pipeline *nextgen.PipelineListResponseBody) map[string]interface{} {
+	summary := map[string]interface{}{
+		"identifier":    pipeline.Identifier,
+		"name":          pipeline.Name,
+		"description":   pipeline.Description,
+		"tags":          helpers.FlattenTags(pipeline.Tags),
+		"modules":       pipeline.Modules,
+		"store_type":    pipelineStoreType(pipeline),
+		"connector_ref": pipeline.ConnectorRef,
+		"updated_at":    int(pipeline.Updated),
+	}
+	if git := pipeline.GitDetails; git != nil {
+		summary["git_details"] = []interface{}{map[string]interface{}{
+			"branch_name":    git.BranchName,
+			"file_path":      git.FilePath,
+			"repo_name":      git.RepoName,
+			"file_url":       git.FileUrl,
+			"last_commit_id": git.CommitId,
+			"last_object_id": git.ObjectId,
+		}}
+	}
+	// Recent executions are listed most recent first.
+	if len(pipeline.RecentExecutionInfo) > 0 {
+		last := pipeline.RecentExecutionInfo[0]
+		summary["last_execution_id"] = last.ExecutionId
+		summary["last_execution_status"] = last.ExecutionStatus
+		summary["last_execution_started_at"] = int(last.Started)
+	}
+	return summary
+}
+
+// pipelineStoreType returns the store type of a pipeline, which is not set
+// for pipelines created before Git Experience.
+func pipelineStoreType(pipeline *nextgen.PipelineListResponseBody) string {
+	if pipeline.StoreType == "" {
+		return "INLINE"
+	}
+	return pipeline.StoreType
+}
+
+// hasAllTags reports whether every wanted tag, given as `name` or
+// `name:value`, is present on the pipeline.
+func hasAllTags(pipelineTags map[string]string, wanted []string) bool {
+	for _, w := range wanted {
+		name, value, hasValue := strings.Cut(w, ":")
+		actual, ok := pipelineTags[name]
+		if !ok || (hasValue && actual != value) {
+			return false
+		}
+	}
+	return true
+}
+
+func usesTemplate(text string, templateRef string) (bool, error) {
+	refs, err := helpers.TemplateReferences(text)
+	if err != nil {
+		return false, err
+	}
+	for _, ref := range refs {
+		if ref.TemplateRef == templateRef {
+			return true, nil
+		}
+	}
+	return false, nil
+}
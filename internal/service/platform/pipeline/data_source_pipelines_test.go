@@ -0,0 +1,200 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePipelines(t *testing.T) {
+
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePipelines(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.all", "pipelines.#", "2"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.tagged", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.tagged", "pipelines.0.identifier", "tagged"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.tagged", "pipelines.0.store_type", "INLINE"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.searched", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.searched", "pipelines.0.identifier", "untagged"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.inline", "pipelines.#", "2"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.remote", "pipelines.#", "0"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.ci", "pipelines.#", "0"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.templated", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.templated", "pipelines.0.identifier", "untagged"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.filtered", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.harness_platform_pipelines.filtered", "pipelines.0.identifier", "tagged"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePipelines(id string, name string) string {
+	return fmt.Sprintf(`
+	resource "harness_platform_organization" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+	}
+
+	resource "harness_platform_project" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_organization.test.id
+		color = "#472848"
+	}
+
+	resource "harness_platform_template" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		version = "v1"
+		is_stable = true
+		template_yaml = <<-EOT
+		template:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      versionLabel: v1
+      type: Step
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      spec:
+        type: ShellScript
+        timeout: 10m
+        spec:
+          shell: Bash
+          onDelegate: true
+          source:
+            type: Inline
+            spec:
+              script: echo hello
+          environmentVariables: []
+          outputVariables: []
+      EOT
+	}
+
+	resource "harness_platform_pipeline_filters" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		type = "PipelineSetup"
+		filter_properties {
+			tags = ["team:platform"]
+			filter_type = "PipelineSetup"
+		}
+		filter_visibility = "EveryOne"
+	}
+
+	resource "harness_platform_pipeline" "test" {
+		for_each = toset(["tagged", "untagged"])
+		identifier = each.key
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = each.key
+		yaml = <<-EOT
+		pipeline:
+      name: ${each.key}
+      identifier: ${each.key}
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags:
+        ${each.key == "tagged" ? "team: platform" : "team: other"}
+      stages:
+        - stage:
+            name: greet
+            identifier: greet
+            type: Custom
+            spec:
+              execution:
+                steps:
+                  - step:
+                      name: hello
+                      identifier: hello
+      %%{~ if each.key == "untagged" }
+                      template:
+                        templateRef: ${harness_platform_template.test.id}
+                        versionLabel: v1
+      %%{~ else }
+                      type: ShellScript
+                      timeout: 10m
+                      spec:
+                        shell: Bash
+                        onDelegate: true
+                        source:
+                          type: Inline
+                          spec:
+                            script: echo hello
+      %%{~ endif }
+      EOT
+	}
+
+	data "harness_platform_pipelines" "all" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "tagged" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		tags = ["team:platform"]
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "searched" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		search_term = "untagged"
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "inline" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		store_type = "INLINE"
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "remote" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		store_type = "REMOTE"
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	# Both pipelines only have Custom stages.
+	data "harness_platform_pipelines" "ci" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		module = "CI"
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "templated" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		template_ref = harness_platform_template.test.id
+		depends_on = [harness_platform_pipeline.test]
+	}
+
+	data "harness_platform_pipelines" "filtered" {
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		filter_id = harness_platform_pipeline_filters.test.id
+		depends_on = [harness_platform_pipeline.test]
+	}
+	`, id, name)
+}
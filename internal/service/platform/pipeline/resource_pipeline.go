@@ -2,7 +2,11 @@ package pipeline
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/antihax/optional"
 	"github.com/harness/harness-openapi-go-client/nextgen"
@@ -13,6 +17,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// pipelineLocks serializes the changes made to a pipeline by the resources
+// sharing its YAML, so that changes applied in parallel don't overwrite
+// each other.
+var pipelineLocks sync.Map
+
 func ResourcePipeline() *schema.Resource {
 	resource := &schema.Resource{
 		Description: "Resource for creating a Harness pipeline.",
@@ -21,13 +30,15 @@ func ResourcePipeline() *schema.Resource {
 		UpdateContext: resourcePipelineCreateOrUpdate,
 		DeleteContext: resourcePipelineDelete,
 		CreateContext: resourcePipelineCreateOrUpdate,
+		CustomizeDiff: resourcePipelineCustomizeDiff,
 		Importer:      helpers.ProjectResourceImporter,
 
 		Schema: map[string]*schema.Schema{
 			"yaml": {
-				Description: "YAML of the pipeline." + helpers.Descriptions.YamlText.String(),
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "YAML of the pipeline." + helpers.Descriptions.YamlText.String(),
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressPreservedNotificationRules,
 			},
 			"git_details": {
 				Description: "Contains parameters related to creating an Entity for Git Experience.",
@@ -74,16 +85,28 @@ func ResourcePipeline() *schema.Resource {
 							Optional:    true,
 						},
 						"last_object_id": {
-							Description: "Last object identifier (for Github). To be provided only when updating Pipeline.",
+							Description: "Last object identifier (for Github) written by Terraform. Updates fail if the file has changed in Git since, see `acknowledged_commit_id`. A configured value is used instead of the recorded one.",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
+							Deprecated:  "This is set by Terraform. Configured values will be ignored in a future release, use acknowledged_commit_id to overwrite a file changed in Git instead.",
 						},
 						"last_commit_id": {
-							Description: "Last commit identifier (for Git Repositories other than Github). To be provided only when updating Pipeline.",
+							Description: "Last commit identifier (for Git Repositories other than Github) written by Terraform. Updates fail if the file has changed in Git since, see `acknowledged_commit_id`. A configured value is used instead of the recorded one.",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
+							Deprecated:  "This is set by Terraform. Configured values will be ignored in a future release, use acknowledged_commit_id to overwrite a file changed in Git instead.",
+						},
+						"last_yaml_hash": {
+							Description: "Hash of the YAML Terraform last wrote to Git, leaving out the notification rules. It tells apart the commits that only changed notification rules, see `preserve_notification_rules`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"acknowledged_commit_id": {
+							Description: "Commit identifier, or object identifier for Github, of a change made to the file in Git that the configured yaml has been reconciled with. An update that fails because the file has changed in Git since Terraform last wrote it goes ahead when the file is at this version, overwriting it. It has no effect once Terraform has written the file again, so it can be left in the configuration.",
+							Type:        schema.TypeString,
+							Optional:    true,
 						},
 					},
 				},
@@ -98,6 +121,23 @@ func ResourcePipeline() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"runtime_inputs_template": {
+				Description: "Skeleton of the runtime inputs (`<+input>`) of the pipeline, under a `pipeline:` root like the pipeline YAML. Only the fields set to `<+input>` are kept, along with the identifiers and types that locate them, so the body of an input set can be built from it. It is derived from the YAML stored in Harness, so it is known after apply whenever `yaml` changes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"preserve_notification_rules": {
+				Description: "Keep the notification rules of the pipeline that are not in `yaml`, such as those managed with `harness_platform_pipeline_notification`. They are not reported as changes to `yaml` and are carried over when the pipeline is updated, so a rule removed from `yaml` is kept too. Commits to a remote pipeline that only change its notification rules are not treated as changes made in Git. Defaults to false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"validate_remotely": {
+				Description: "Validate the YAML against the Harness pipeline schema during plan, in addition to the local checks. This calls Harness whenever `yaml` changes, and is skipped when the organization or project is only known during apply. Set to false to only run the local checks. Defaults to true.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 		},
 	}
 
@@ -115,11 +155,16 @@ func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta inte
 	template_applied := d.Get("template_applied").(bool)
 	var branch_name optional.String
 	branch_name = helpers.BuildField(d, "git_details.0.branch_name")
-	var store_type = helpers.BuildField(d, "git_details.0.store_type")
-	var base_branch = helpers.BuildField(d, "git_details.0.base_branch")
-	var commit_message = helpers.BuildField(d, "git_details.0.commit_message")
-	var connector_ref = helpers.BuildField(d, "git_details.0.connector_ref")
-	var object_id = helpers.BuildField(d, "git_details.0.object_id")
+	git_config := pipelineGitConfig{
+		StoreType:            helpers.BuildField(d, "git_details.0.store_type"),
+		BaseBranch:           helpers.BuildField(d, "git_details.0.base_branch"),
+		CommitMessage:        helpers.BuildField(d, "git_details.0.commit_message"),
+		ConnectorRef:         helpers.BuildField(d, "git_details.0.connector_ref"),
+		AcknowledgedCommitId: helpers.BuildField(d, "git_details.0.acknowledged_commit_id"),
+		LastYamlHash:         helpers.BuildField(d, "git_details.0.last_yaml_hash"),
+	}
+	last_commit_id := d.Get("git_details.0.last_commit_id").(string)
+	last_object_id := d.Get("git_details.0.last_object_id").(string)
 	resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx,
 		org_id,
 		project_id,
@@ -136,11 +181,30 @@ func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta inte
 	if err != nil {
 		return helpers.HandleApiError(err, d, httpResp)
 	}
-	print(object_id.Value())
 
-	readPipeline(d, resp, org_id, project_id, template_applied, store_type, base_branch, commit_message, connector_ref)
+	// The commit and object ids recorded in state are those Terraform last
+	// wrote, so that updates can tell when the file has since been changed
+	// in Git. Refreshing reports such changes without moving them, unless
+	// they only touched preserved notification rules.
+	var diags diag.Diagnostics
+	if resp.GitDetails != nil && (last_commit_id != "" || last_object_id != "") {
+		conflict := remotePipelineConflict(resp.GitDetails, last_commit_id, last_object_id)
+		if conflict == "" || !notificationRulesChangedOnly(d, resp.PipelineYaml) {
+			if conflict != "" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Pipeline %s was changed in Git", id),
+					Detail:   conflict,
+				})
+			}
+			resp.GitDetails.CommitId = last_commit_id
+			resp.GitDetails.ObjectId = last_object_id
+		}
+	}
+
+	readPipeline(d, resp, org_id, project_id, template_applied, git_config)
 
-	return nil
+	return diags
 }
 
 func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -154,11 +218,15 @@ func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData,
 	var commit_message optional.String
 	var connector_ref optional.String
 	var httpResp *http.Response
+	var acknowledged_commit_id = helpers.BuildField(d, "git_details.0.acknowledged_commit_id")
 	id := d.Id()
 	org_id := d.Get("org_id").(string)
 	project_id := d.Get("project_id").(string)
 	template_applied := d.Get("template_applied").(bool)
 
+	unlock := lockPipeline(org_id, project_id, d.Get("identifier").(string))
+	defer unlock()
+
 	if id == "" {
 		pipeline := buildCreatePipeline(d)
 		if pipeline.GitDetails != nil {
@@ -174,6 +242,15 @@ func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData,
 			&nextgen.PipelinesApiCreatePipelineOpts{HarnessAccount: optional.NewString(c.AccountId)})
 	} else {
 		pipeline := buildUpdatePipeline(d)
+		if diags := checkRemotePipelineConflict(ctx, c, d, pipeline.GitDetails); diags.HasError() {
+			// Nothing was written, so keep the previous state.
+			d.Partial(true)
+			return diags
+		}
+		if diags := mergePreservedNotificationRules(ctx, c, d, &pipeline); diags.HasError() {
+			d.Partial(true)
+			return diags
+		}
 		store_type = helpers.BuildField(d, "git_details.0.store_type")
 		connector_ref = helpers.BuildField(d, "git_details.0.connector_ref")
 		pipeline_id = pipeline.Identifier
@@ -197,8 +274,173 @@ func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData,
 		return helpers.HandleApiError(err, d, httpResp)
 	}
 
-	readPipeline(d, resp, org_id, project_id, template_applied, store_type, base_branch, commit_message, connector_ref)
+	readPipeline(d, resp, org_id, project_id, template_applied, pipelineGitConfig{
+		StoreType:            store_type,
+		BaseBranch:           base_branch,
+		CommitMessage:        commit_message,
+		ConnectorRef:         connector_ref,
+		AcknowledgedCommitId: acknowledged_commit_id,
+		LastYamlHash:         optional.NewString(pipelineYamlHash(resp.PipelineYaml)),
+	})
+
+	return nil
+}
+
+func lockPipeline(org_id string, project_id string, pipeline_id string) func() {
+	lock, _ := pipelineLocks.LoadOrStore(fmt.Sprintf("%s/%s/%s", org_id, project_id, pipeline_id), &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+// mergePreservedNotificationRules adds to the YAML to write the notification
+// rules of the pipeline it doesn't have, when they are preserved.
+func mergePreservedNotificationRules(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, pipeline *nextgen.PipelineUpdateRequestBody) diag.Diagnostics {
+	if !d.Get("preserve_notification_rules").(bool) {
+		return nil
+	}
+
+	opts := &nextgen.PipelinesApiGetPipelineOpts{HarnessAccount: optional.NewString(c.AccountId)}
+	if pipeline.GitDetails != nil && pipeline.GitDetails.BranchName != "" {
+		opts.BranchName = optional.NewString(pipeline.GitDetails.BranchName)
+	}
+	resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx, d.Get("org_id").(string), d.Get("project_id").(string), d.Id(), opts)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
 
+	pipelineYaml, err := helpers.MergeYamlListItems(pipeline.PipelineYaml, resp.PipelineYaml, notificationRulesPath)
+	if err != nil {
+		return diag.Errorf("could not keep the notification rules of pipeline %s: %s", d.Id(), err)
+	}
+	pipeline.PipelineYaml = pipelineYaml
+	return nil
+}
+
+// suppressPreservedNotificationRules ignores the notification rules of the
+// pipeline that are not in the configured YAML, when they are preserved.
+func suppressPreservedNotificationRules(k, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("preserve_notification_rules").(bool) || old == "" {
+		return false
+	}
+	merged, err := helpers.MergeYamlListItems(new, old, notificationRulesPath)
+	return err == nil && merged != new && helpers.YamlEqual(merged, old)
+}
+
+// pipelineYamlHash returns a hash of the pipeline YAML leaving out its
+// notification rules, or an empty string if it does not parse.
+func pipelineYamlHash(pipelineYaml string) string {
+	text, err := helpers.RemoveYamlPath(pipelineYaml, notificationRulesPath)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// notificationRulesChangedOnly reports whether the YAML of a remote pipeline
+// only differs from the one Terraform last wrote by its notification rules,
+// when they are preserved. Commits made by
+// harness_platform_pipeline_notification are such changes.
+func notificationRulesChangedOnly(d *schema.ResourceData, pipelineYaml string) bool {
+	if !d.Get("preserve_notification_rules").(bool) {
+		return false
+	}
+	hash := d.Get("git_details.0.last_yaml_hash").(string)
+	return hash != "" && hash == pipelineYamlHash(pipelineYaml)
+}
+
+// checkRemotePipelineConflict fails an update of a remote pipeline whose file
+// was changed in Git since Terraform last wrote it, rather than overwriting
+// the upstream commits. When the change has been acknowledged, or only
+// touched preserved notification rules, the update is made against the
+// current version of the file instead.
+func checkRemotePipelineConflict(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, git_details *nextgen.GitUpdateDetails) diag.Diagnostics {
+	if git_details == nil || d.Get("git_details.0.store_type").(string) != "REMOTE" {
+		return nil
+	}
+	if git_details.LastCommitId == "" && git_details.LastObjectId == "" {
+		return nil
+	}
+
+	resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx, d.Get("org_id").(string), d.Get("project_id").(string), d.Id(),
+		&nextgen.PipelinesApiGetPipelineOpts{HarnessAccount: optional.NewString(c.AccountId), BranchName: optional.NewString(git_details.BranchName)})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if resp.GitDetails == nil {
+		return nil
+	}
+	conflict := remotePipelineConflict(resp.GitDetails, git_details.LastCommitId, git_details.LastObjectId)
+	if conflict == "" {
+		return nil
+	}
+	acknowledged := d.Get("git_details.0.acknowledged_commit_id").(string)
+	if acknowledged != "" && (acknowledged == resp.GitDetails.CommitId || acknowledged == resp.GitDetails.ObjectId) || notificationRulesChangedOnly(d, resp.PipelineYaml) {
+		git_details.LastCommitId = resp.GitDetails.CommitId
+		git_details.LastObjectId = resp.GitDetails.ObjectId
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Pipeline %s was changed in Git", d.Id()),
+		Detail:   conflict + fmt.Sprintf(" Reconcile the yaml with the file, then set git_details.acknowledged_commit_id to %s to overwrite it.", remotePipelineVersion(resp.GitDetails)),
+	}}
+}
+
+// remotePipelineVersion returns the commit id of the file in Git, or its
+// object id when there is none.
+func remotePipelineVersion(current *nextgen.GitDetails) string {
+	if current.CommitId != "" {
+		return current.CommitId
+	}
+	return current.ObjectId
+}
+
+// remotePipelineConflict describes how the file in Git moved away from the
+// given commit and object ids, or returns an empty string if it did not.
+func remotePipelineConflict(current *nextgen.GitDetails, last_commit_id string, last_object_id string) string {
+	if last_commit_id != "" && current.CommitId != "" && current.CommitId != last_commit_id {
+		return fmt.Sprintf("%s on branch %s is at commit %s, Terraform last wrote commit %s.", current.FilePath, current.BranchName, current.CommitId, last_commit_id)
+	}
+	if last_object_id != "" && current.ObjectId != "" && current.ObjectId != last_object_id {
+		return fmt.Sprintf("%s on branch %s is at object %s, Terraform last wrote object %s.", current.FilePath, current.BranchName, current.ObjectId, last_object_id)
+	}
+	return ""
+}
+
+// resourcePipelineCustomizeDiff rejects broken pipeline YAML during plan,
+// before any other resource of the apply has been changed.
+func resourcePipelineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The runtime inputs template is always derived from the YAML Harness
+	// stores, which may be formatted differently from the configured one, so
+	// it is only known once the new YAML has been written.
+	if !d.NewValueKnown("yaml") || d.HasChange("yaml") {
+		if err := d.SetNewComputed("runtime_inputs_template"); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("yaml") {
+		return nil
+	}
+
+	yaml := d.Get("yaml").(string)
+	if err := helpers.ValidateEntityYaml(yaml, "pipeline"); err != nil {
+		return fmt.Errorf("invalid pipeline YAML: %w", err)
+	}
+
+	// The project may not exist yet, in which case its identifier is only
+	// known during apply and validation against Harness is skipped.
+	if !d.Get("validate_remotely").(bool) || !d.HasChange("yaml") || !d.NewValueKnown("org_id") || !d.NewValueKnown("project_id") {
+		return nil
+	}
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	_, httpResp, err := c.PipelinesApi.PostPipeline1(ctx, yaml, c.AccountId, d.Get("org_id").(string), d.Get("project_id").(string))
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("could not validate pipeline YAML: organization %s or project %s not found", d.Get("org_id").(string), d.Get("project_id").(string))
+		}
+		return fmt.Errorf("invalid pipeline YAML: %s", err)
+	}
 	return nil
 }
 
@@ -209,6 +451,9 @@ func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, meta in
 	org_id := d.Get("org_id").(string)
 	project_id := d.Get("project_id").(string)
 
+	unlock := lockPipeline(org_id, project_id, id)
+	defer unlock()
+
 	httpResp, err := c.PipelinesApi.DeletePipeline(ctx, org_id, project_id, id, &nextgen.PipelinesApiDeletePipelineOpts{
 		HarnessAccount: optional.NewString(c.AccountId),
 	})
@@ -292,23 +537,37 @@ func buildUpdatePipeline(d *schema.ResourceData) nextgen.PipelineUpdateRequestBo
 	return pipeline
 }
 
+// pipelineGitConfig holds the attributes of git_details that the API does
+// not return, to be kept as they are when set.
+type pipelineGitConfig struct {
+	StoreType            optional.String
+	BaseBranch           optional.String
+	CommitMessage        optional.String
+	ConnectorRef         optional.String
+	AcknowledgedCommitId optional.String
+	LastYamlHash         optional.String
+}
+
 // Read response from API out to the stored identifiers
-func readPipeline(d *schema.ResourceData, pipeline nextgen.PipelineGetResponseBody, org_id string, project_id string, template_applied bool, store_type optional.String, base_branch optional.String, commit_message optional.String, connector_ref optional.String) {
+func readPipeline(d *schema.ResourceData, pipeline nextgen.PipelineGetResponseBody, org_id string, project_id string, template_applied bool, git_config pipelineGitConfig) {
 	d.SetId(pipeline.Identifier)
 	d.Set("identifier", pipeline.Identifier)
 	d.Set("name", pipeline.Name)
 	d.Set("org_id", org_id)
 	d.Set("project_id", project_id)
 	d.Set("yaml", pipeline.PipelineYaml)
+	if template, err := helpers.RuntimeInputsTemplate(pipeline.PipelineYaml); err == nil {
+		d.Set("runtime_inputs_template", template)
+	}
 	d.Set("description", pipeline.Description)
 	d.Set("template_applied_pipeline_yaml", pipeline.TemplateAppliedPipelineYaml)
 	d.Set("template_applied", template_applied)
 	if pipeline.GitDetails != nil {
-		d.Set("git_details", []interface{}{readGitDetails(pipeline, store_type, base_branch, commit_message, connector_ref)})
+		d.Set("git_details", []interface{}{readGitDetails(pipeline, git_config)})
 	}
 }
 
-func readGitDetails(pipeline nextgen.PipelineGetResponseBody, store_type optional.String, base_branch optional.String, commit_message optional.String, connector_ref optional.String) map[string]interface{} {
+func readGitDetails(pipeline nextgen.PipelineGetResponseBody, git_config pipelineGitConfig) map[string]interface{} {
 	git_details := map[string]interface{}{
 		"branch_name":    pipeline.GitDetails.BranchName,
 		"file_path":      pipeline.GitDetails.FilePath,
@@ -316,17 +575,23 @@ func readGitDetails(pipeline nextgen.PipelineGetResponseBody, store_type optiona
 		"last_commit_id": pipeline.GitDetails.CommitId,
 		"last_object_id": pipeline.GitDetails.ObjectId,
 	}
-	if store_type.IsSet() {
-		git_details["store_type"] = store_type.Value()
+	if git_config.StoreType.IsSet() {
+		git_details["store_type"] = git_config.StoreType.Value()
+	}
+	if git_config.BaseBranch.IsSet() {
+		git_details["base_branch"] = git_config.BaseBranch.Value()
+	}
+	if git_config.CommitMessage.IsSet() {
+		git_details["commit_message"] = git_config.CommitMessage.Value()
 	}
-	if base_branch.IsSet() {
-		git_details["base_branch"] = base_branch.Value()
+	if git_config.ConnectorRef.IsSet() {
+		git_details["connector_ref"] = git_config.ConnectorRef.Value()
 	}
-	if commit_message.IsSet() {
-		git_details["commit_message"] = commit_message.Value()
+	if git_config.AcknowledgedCommitId.IsSet() {
+		git_details["acknowledged_commit_id"] = git_config.AcknowledgedCommitId.Value()
 	}
-	if connector_ref.IsSet() {
-		git_details["connector_ref"] = connector_ref.Value()
+	if git_config.LastYamlHash.IsSet() {
+		git_details["last_yaml_hash"] = git_config.LastYamlHash.Value()
 	}
 	return git_details
 }
@@ -0,0 +1,289 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const defaultExecutionWaitTimeout = 30 * time.Minute
+
+// terminalExecutionStatuses are the execution statuses a pipeline execution
+// does not leave again.
+var terminalExecutionStatuses = map[string]bool{
+	"Success":          true,
+	"IgnoreFailed":     true,
+	"Failed":           true,
+	"Errored":          true,
+	"Expired":          true,
+	"Aborted":          true,
+	"ApprovalRejected": true,
+	"Skipped":          true,
+}
+
+func ResourcePipelineExecution() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for running a Harness pipeline. A new execution is started whenever `triggers` or any other argument changes.",
+
+		CreateContext: resourcePipelineExecutionCreate,
+		ReadContext:   resourcePipelineExecutionRead,
+		DeleteContext: resourcePipelineExecutionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Description: "Organization identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline_id": {
+				Description: "Identifier of the pipeline to run.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"module": {
+				Description: "Module the pipeline is run in, such as `cd` or `ci`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "cd",
+			},
+			"branch": {
+				Description: "Branch to run the pipeline from. Only used with remote pipelines.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"input_set_ids": {
+				Description:   "Identifiers of the input sets to run the pipeline with.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"runtime_input_yaml"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"runtime_input_yaml": {
+				Description:   "Runtime input YAML to run the pipeline with." + helpers.Descriptions.YamlText.String(),
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"input_set_ids"},
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, starts a new execution.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_completion": {
+				Description: "Wait for the execution to reach a terminal status, and fail if it did not succeed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"wait_timeout": {
+				Description:  "Maximum time to wait for the execution to complete, as a duration such as \"1h\". Defaults to 30 minutes.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: helpers.ValidateDuration,
+			},
+			"execution_id": {
+				Description: "Identifier of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"outputs": {
+				Description: "Output variables published by the steps of the execution, keyed by the fully qualified name of the step followed by the variable, as in `stages.<stage>.spec.execution.steps.<step>.<variable>`. This matches the `<+pipeline.stages.<stage>.spec.execution.steps.<step>.output.outputVariables.<variable>>` expression without the `output.outputVariables` part.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"execution_url": {
+				Description: "Link to the execution in the Harness UI.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+	return resource
+}
+
+func resourcePipelineExecutionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	session := meta.(*internal.Session)
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+	module := d.Get("module").(string)
+	branch := helpers.BuildField(d, "branch")
+
+	var resp nextgen.ResponseDtoPlanExecutionResponse
+	var httpResp *http.Response
+	var err error
+	if inputSetIds := d.Get("input_set_ids").([]interface{}); len(inputSetIds) > 0 {
+		resp, httpResp, err = c.ExecuteApi.PostPipelineExecuteWithInputSetList(ctx, nextgen.MergeInputSetRequest{
+			InputSetReferences: helpers.ExpandField(inputSetIds),
+		}, c.AccountId, orgId, projectId, module, pipelineId, &nextgen.ExecuteApiPostPipelineExecuteWithInputSetListOpts{
+			Branch: branch,
+		})
+	} else {
+		resp, httpResp, err = c.ExecuteApi.PostPipelineExecuteWithInputSetYaml(ctx, c.AccountId, orgId, projectId, module, pipelineId, &nextgen.ExecuteApiPostPipelineExecuteWithInputSetYamlOpts{
+			Body:   optional.NewInterface(d.Get("runtime_input_yaml").(string)),
+			Branch: branch,
+		})
+	}
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if resp.Data == nil || resp.Data.PlanExecution == nil {
+		return diag.Errorf("pipeline %s was not started", pipelineId)
+	}
+
+	executionId := resp.Data.PlanExecution.Uuid
+	d.SetId(executionId)
+	d.Set("execution_id", executionId)
+	d.Set("execution_url", executionUrl(session, module, orgId, projectId, pipelineId, executionId))
+
+	if !d.Get("wait_for_completion").(bool) {
+		return resourcePipelineExecutionRead(ctx, d, meta)
+	}
+
+	timeout := defaultExecutionWaitTimeout
+	if attr, ok := d.GetOk("wait_timeout"); ok {
+		timeout, _ = time.ParseDuration(attr.(string))
+	}
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		detail, httpResp, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, orgId, projectId, executionId, &nextgen.ExecutionDetailsApiGetExecutionDetailV2Opts{
+			RenderFullBottomGraph: optional.NewBool(true),
+		})
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode >= 500 {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		if detail.Data == nil || detail.Data.PipelineExecutionSummary == nil {
+			return retry.RetryableError(fmt.Errorf("waiting for execution %s of pipeline %s to start", executionId, pipelineId))
+		}
+
+		setPipelineExecutionDetail(d, detail.Data)
+		summary := detail.Data.PipelineExecutionSummary
+		if !terminalExecutionStatuses[summary.Status] {
+			return retry.RetryableError(fmt.Errorf("waiting for execution %s of pipeline %s to complete, currently %s", executionId, pipelineId, summary.Status))
+		}
+		if summary.Status != "Success" && summary.Status != "IgnoreFailed" {
+			message := ""
+			if summary.ExecutionErrorInfo != nil {
+				message = summary.ExecutionErrorInfo.Message
+			}
+			return retry.NonRetryableError(fmt.Errorf("execution %s of pipeline %s ended with status %s: %s", executionId, pipelineId, summary.Status, message))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePipelineExecutionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	detail, httpResp, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, d.Get("org_id").(string), d.Get("project_id").(string), d.Id(), &nextgen.ExecutionDetailsApiGetExecutionDetailV2Opts{
+		RenderFullBottomGraph: optional.NewBool(true),
+	})
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	// Soft delete lookup error handling
+	// https://harness.atlassian.net/browse/PL-23765
+	if detail.Data == nil || detail.Data.PipelineExecutionSummary == nil {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	setPipelineExecutionDetail(d, detail.Data)
+	return nil
+}
+
+func resourcePipelineExecutionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// An execution cannot be undone; removing the resource only forgets it.
+	d.SetId("")
+	return nil
+}
+
+func setPipelineExecutionDetail(d *schema.ResourceData, detail *nextgen.PipelineExecutionDetail) {
+	summary := detail.PipelineExecutionSummary
+	d.Set("execution_id", summary.PlanExecutionId)
+	d.Set("status", summary.Status)
+
+	outputs := map[string]interface{}{}
+	if detail.ExecutionGraph != nil {
+		for _, node := range detail.ExecutionGraph.NodeMap {
+			output, ok := node.Outcomes["output"]
+			if !ok {
+				continue
+			}
+			variables, ok := output["outputVariables"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, value := range variables {
+				outputs[executionNodePath(node)+"."+name] = fmt.Sprintf("%v", value)
+			}
+		}
+	}
+	d.Set("outputs", outputs)
+}
+
+// executionNodePath returns the fully qualified name of a node relative to
+// the pipeline, so that steps with the same identifier in different stages or
+// step groups do not collide.
+func executionNodePath(node nextgen.ExecutionNode) string {
+	if node.BaseFqn == "" {
+		return node.Identifier
+	}
+	return strings.TrimPrefix(node.BaseFqn, "pipeline.")
+}
+
+// executionUrl builds the link to an execution in the Harness UI, which is
+// served from the same host as the API gateway.
+func executionUrl(session *internal.Session, module string, orgId string, projectId string, pipelineId string, executionId string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(session.Endpoint, "/"), "/gateway")
+	return fmt.Sprintf("%s/ng/account/%s/module/%s/orgs/%s/projects/%s/pipelines/%s/executions/%s/pipeline", base, session.AccountId, module, orgId, projectId, pipelineId, executionId)
+}
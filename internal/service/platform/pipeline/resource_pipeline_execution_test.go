@@ -0,0 +1,106 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourcePipelineExecution(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resourceName := "harness_platform_pipeline_execution.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineExecution(id, name, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.stages.custom.spec.execution.steps.echo.greeting", "hello"),
+					resource.TestCheckResourceAttrSet(resourceName, "execution_url"),
+				),
+			},
+			{
+				Config: testAccResourcePipelineExecution(id, name, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePipelineExecution(id string, name string, run string) string {
+	return testAccPipelineExecutionPipeline(id, name) + fmt.Sprintf(`
+		resource "harness_platform_pipeline_execution" "test" {
+			org_id = harness_platform_pipeline.test.org_id
+			project_id = harness_platform_pipeline.test.project_id
+			pipeline_id = harness_platform_pipeline.test.id
+			wait_for_completion = true
+			wait_timeout = "10m"
+			triggers = {
+				run = "%[1]s"
+			}
+		}
+	`, run)
+}
+
+func testAccPipelineExecutionPipeline(id string, name string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+		}
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_organization.test.id
+		}
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[2]s"
+			yaml = <<-EOT
+				pipeline:
+				  name: %[2]s
+				  identifier: %[1]s
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  orgIdentifier: ${harness_platform_project.test.org_id}
+				  stages:
+				    - stage:
+				        name: custom
+				        identifier: custom
+				        type: Custom
+				        spec:
+				          execution:
+				            steps:
+				              - step:
+				                  name: echo
+				                  identifier: echo
+				                  type: ShellScript
+				                  timeout: 5m
+				                  spec:
+				                    shell: Bash
+				                    onDelegate: true
+				                    source:
+				                      type: Inline
+				                      spec:
+				                        script: export greeting=hello
+				                    environmentVariables: []
+				                    outputVariables:
+				                      - name: greeting
+				                        type: String
+				                        value: greeting
+			EOT
+		}
+	`, id, name)
+}
@@ -0,0 +1,589 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// notificationRulesPath is where notification rules are kept in the
+// pipeline YAML.
+const notificationRulesPath = "pipeline.notificationRules"
+
+// allStages is the value of forStages that matches every stage.
+const allStages = "AllStages"
+
+const secretRefText = " To reference a secret at the organization scope, prefix 'org' to the expression: org.{identifier}. To reference a secret at the account scope, prefix 'account' to the expression: account.{identifier}."
+
+const userGroupRefText = " To reference a user group at the organization scope, prefix 'org' to the expression: org.{identifier}. To reference a user group at the account scope, prefix 'account' to the expression: account.{identifier}."
+
+var notificationEventTypes = []string{
+	"AllEvents",
+	"PipelineStart",
+	"PipelineEnd",
+	"PipelineSuccess",
+	"PipelineFailed",
+	"PipelinePaused",
+	"StageStart",
+	"StageSuccess",
+	"StageFailed",
+	"StepFailed",
+}
+
+var notificationChannels = []string{"slack", "email", "pagerduty", "msteams", "webhook"}
+
+var secretExpressionPattern = regexp.MustCompile(`^<\+secrets\.getValue\("([^"]+)"\)>$`)
+
+func ResourcePipelineNotification() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for managing a notification rule of a Harness pipeline. The rule is merged into the `notificationRules` of the pipeline YAML, leaving the rest of the pipeline as it is. " +
+			"A pipeline whose YAML is also managed with `harness_platform_pipeline` should set `preserve_notification_rules` there, so that the rule is neither reported as a change to its `yaml` nor removed by its updates, and the commits made to a remote pipeline by this resource are not taken for changes made in Git.",
+
+		ReadContext:   resourcePipelineNotificationRead,
+		UpdateContext: resourcePipelineNotificationCreateOrUpdate,
+		CreateContext: resourcePipelineNotificationCreateOrUpdate,
+		DeleteContext: resourcePipelineNotificationDelete,
+		Importer:      helpers.PipelineResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Unique identifier of the notification rule within the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the notification rule.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Unique identifier of the organization.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Unique identifier of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pipeline_id": {
+				Description: "Identifier of the pipeline",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"branch": {
+				Description: "Branch of a remote pipeline to change. Defaults to the default branch of the repository.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"commit_message": {
+				Description: "Commit message used when changing a remote pipeline.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the notification rule is enabled.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"pipeline_events": {
+				Description: "Events to notify of.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description:  fmt.Sprintf("Type of the event. Valid values are %s.", strings.Join(notificationEventTypes, ", ")),
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(notificationEventTypes, false),
+						},
+						"for_stages": {
+							Description: "Identifiers of the stages to notify of, for stage events. Defaults to all stages.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"slack": {
+				Description:  "Notify through Slack.",
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: notificationChannels,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"webhook_url": {
+							Description:   "Slack webhook URL.",
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"slack.0.webhook_url_secret"},
+						},
+						"webhook_url_secret": {
+							Description:   "Identifier of the secret holding the Slack webhook URL." + secretRefText,
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"slack.0.webhook_url"},
+						},
+						"user_groups": notificationUserGroupsSchema(),
+					},
+				},
+			},
+			"email": {
+				Description:  "Notify by email.",
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: notificationChannels,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"recipients": {
+							Description: "Email addresses to notify.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"user_groups": notificationUserGroupsSchema(),
+					},
+				},
+			},
+			"pagerduty": {
+				Description:  "Notify through PagerDuty.",
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: notificationChannels,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"integration_key": {
+							Description:   "PagerDuty integration key.",
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"pagerduty.0.integration_key_secret"},
+						},
+						"integration_key_secret": {
+							Description:   "Identifier of the secret holding the PagerDuty integration key." + secretRefText,
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"pagerduty.0.integration_key"},
+						},
+						"user_groups": notificationUserGroupsSchema(),
+					},
+				},
+			},
+			"msteams": {
+				Description:  "Notify through Microsoft Teams.",
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: notificationChannels,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Description: "Microsoft Teams webhook URLs.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Sensitive:   true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"key_secrets": {
+							Description: "Identifiers of the secrets holding Microsoft Teams webhook URLs." + secretRefText,
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"user_groups": notificationUserGroupsSchema(),
+					},
+				},
+			},
+			"webhook": {
+				Description:  "Notify by calling a webhook.",
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: notificationChannels,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description:  "URL of the webhook.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"webhook.0.url", "webhook.0.url_secret"},
+						},
+						"url_secret": {
+							Description:  "Identifier of the secret holding the URL of the webhook." + secretRefText,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"webhook.0.url", "webhook.0.url_secret"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func notificationUserGroupsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Identifiers of the user groups to notify through the notification preferences of their members." + userGroupRefText,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+type notificationRule struct {
+	Name               string              `yaml:"name"`
+	Identifier         string              `yaml:"identifier"`
+	PipelineEvents     []notificationEvent `yaml:"pipelineEvents"`
+	NotificationMethod notificationMethod  `yaml:"notificationMethod"`
+	Enabled            bool                `yaml:"enabled"`
+}
+
+type notificationEvent struct {
+	Type      string   `yaml:"type"`
+	ForStages []string `yaml:"forStages,omitempty"`
+}
+
+type notificationMethod struct {
+	Type string                 `yaml:"type"`
+	Spec notificationMethodSpec `yaml:"spec"`
+}
+
+type notificationMethodSpec struct {
+	// UserGroups is nil for webhooks, and rendered even when empty for the
+	// other methods.
+	UserGroups     *[]string `yaml:"userGroups,omitempty"`
+	WebhookUrl     string    `yaml:"webhookUrl,omitempty"`
+	Recipients     []string  `yaml:"recipients,omitempty"`
+	IntegrationKey string    `yaml:"integrationKey,omitempty"`
+	MsTeamKeys     []string  `yaml:"msTeamKeys,omitempty"`
+}
+
+func resourcePipelineNotificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	resp, httpResp, err := getNotificationPipeline(ctx, c, d)
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	var rule notificationRule
+	found, err := helpers.YamlListItem(resp.PipelineYaml, notificationRulesPath, d.Id(), &rule)
+	if err != nil {
+		return diag.Errorf("invalid YAML of pipeline %s: %s", resp.Identifier, err)
+	}
+	if !found {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	readPipelineNotification(d, &rule)
+
+	return nil
+}
+
+func resourcePipelineNotificationCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	unlock := lockPipeline(d.Get("org_id").(string), d.Get("project_id").(string), d.Get("pipeline_id").(string))
+	defer unlock()
+
+	resp, httpResp, err := getNotificationPipeline(ctx, c, d)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	rule := buildPipelineNotification(d)
+	pipelineYaml, err := helpers.SetYamlListItem(resp.PipelineYaml, notificationRulesPath, rule.Identifier, rule)
+	if err != nil {
+		return diag.Errorf("invalid YAML of pipeline %s: %s", resp.Identifier, err)
+	}
+
+	if diags := updateNotificationPipeline(ctx, c, d, resp, pipelineYaml); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(rule.Identifier)
+	readPipelineNotification(d, rule)
+
+	return nil
+}
+
+func resourcePipelineNotificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	unlock := lockPipeline(d.Get("org_id").(string), d.Get("project_id").(string), d.Get("pipeline_id").(string))
+	defer unlock()
+
+	resp, httpResp, err := getNotificationPipeline(ctx, c, d)
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	pipelineYaml, found, err := helpers.RemoveYamlListItem(resp.PipelineYaml, notificationRulesPath, d.Id())
+	if err != nil {
+		return diag.Errorf("invalid YAML of pipeline %s: %s", resp.Identifier, err)
+	}
+	if !found {
+		return nil
+	}
+
+	return updateNotificationPipeline(ctx, c, d, resp, pipelineYaml)
+}
+
+func getNotificationPipeline(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData) (nextgen.PipelineGetResponseBody, *http.Response, error) {
+	return c.PipelinesApi.GetPipeline(ctx, d.Get("org_id").(string), d.Get("project_id").(string), d.Get("pipeline_id").(string),
+		&nextgen.PipelinesApiGetPipelineOpts{HarnessAccount: optional.NewString(c.AccountId), BranchName: helpers.BuildField(d, "branch")})
+}
+
+// updateNotificationPipeline saves the pipeline with the new YAML. Remote
+// pipelines are committed on top of the version that was read, so that
+// changes made in Git in between are not overwritten.
+func updateNotificationPipeline(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, pipeline nextgen.PipelineGetResponseBody, pipelineYaml string) diag.Diagnostics {
+	body := nextgen.PipelineUpdateRequestBody{
+		Identifier:   pipeline.Identifier,
+		Name:         pipeline.Name,
+		Description:  pipeline.Description,
+		Tags:         pipeline.Tags,
+		PipelineYaml: pipelineYaml,
+	}
+	if git := pipeline.GitDetails; git != nil && (git.CommitId != "" || git.ObjectId != "") {
+		commitMessage := d.Get("commit_message").(string)
+		if commitMessage == "" {
+			commitMessage = fmt.Sprintf("Update notification rule %s", d.Get("identifier").(string))
+		}
+		body.GitDetails = &nextgen.GitUpdateDetails{
+			BranchName:    git.BranchName,
+			CommitMessage: commitMessage,
+			LastCommitId:  git.CommitId,
+			LastObjectId:  git.ObjectId,
+		}
+	}
+
+	_, httpResp, err := c.PipelinesApi.UpdatePipeline(ctx, body, d.Get("org_id").(string), d.Get("project_id").(string), pipeline.Identifier,
+		&nextgen.PipelinesApiUpdatePipelineOpts{HarnessAccount: optional.NewString(c.AccountId)})
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	return nil
+}
+
+func buildPipelineNotification(d *schema.ResourceData) *notificationRule {
+	rule := &notificationRule{
+		Name:       d.Get("name").(string),
+		Identifier: d.Get("identifier").(string),
+		Enabled:    d.Get("enabled").(bool),
+	}
+
+	for _, e := range d.Get("pipeline_events").([]interface{}) {
+		event := e.(map[string]interface{})
+		pipelineEvent := notificationEvent{Type: event["type"].(string)}
+		if isStageEvent(pipelineEvent.Type) {
+			pipelineEvent.ForStages = helpers.ExpandField(event["for_stages"].([]interface{}))
+			if len(pipelineEvent.ForStages) == 0 {
+				pipelineEvent.ForStages = []string{allStages}
+			}
+		}
+		rule.PipelineEvents = append(rule.PipelineEvents, pipelineEvent)
+	}
+
+	method := &rule.NotificationMethod
+	if config, ok := notificationChannelConfig(d, "slack"); ok {
+		method.Type = "Slack"
+		method.Spec.UserGroups = expandUserGroups(config)
+		method.Spec.WebhookUrl = secretOrValue(configString(config, "webhook_url_secret"), configString(config, "webhook_url"))
+	}
+	if config, ok := notificationChannelConfig(d, "email"); ok {
+		method.Type = "Email"
+		method.Spec.UserGroups = expandUserGroups(config)
+		method.Spec.Recipients = configList(config, "recipients")
+	}
+	if config, ok := notificationChannelConfig(d, "pagerduty"); ok {
+		method.Type = "PagerDuty"
+		method.Spec.UserGroups = expandUserGroups(config)
+		method.Spec.IntegrationKey = secretOrValue(configString(config, "integration_key_secret"), configString(config, "integration_key"))
+	}
+	if config, ok := notificationChannelConfig(d, "msteams"); ok {
+		method.Type = "MsTeams"
+		method.Spec.UserGroups = expandUserGroups(config)
+		method.Spec.MsTeamKeys = configList(config, "keys")
+		for _, secret := range configList(config, "key_secrets") {
+			method.Spec.MsTeamKeys = append(method.Spec.MsTeamKeys, secretExpression(secret))
+		}
+	}
+	if config, ok := notificationChannelConfig(d, "webhook"); ok {
+		method.Type = "Webhook"
+		method.Spec.WebhookUrl = secretOrValue(configString(config, "url_secret"), configString(config, "url"))
+	}
+
+	return rule
+}
+
+func readPipelineNotification(d *schema.ResourceData, rule *notificationRule) {
+	d.Set("identifier", rule.Identifier)
+	d.Set("name", rule.Name)
+	d.Set("enabled", rule.Enabled)
+
+	var events []interface{}
+	for _, e := range rule.PipelineEvents {
+		forStages := e.ForStages
+		if len(forStages) == 1 && forStages[0] == allStages {
+			forStages = nil
+		}
+		events = append(events, map[string]interface{}{
+			"type":       e.Type,
+			"for_stages": forStages,
+		})
+	}
+	d.Set("pipeline_events", events)
+
+	spec := rule.NotificationMethod.Spec
+	var userGroups []string
+	if spec.UserGroups != nil {
+		userGroups = *spec.UserGroups
+	}
+	channels := map[string][]interface{}{}
+	switch rule.NotificationMethod.Type {
+	case "Slack":
+		secret, value := splitSecretExpression(spec.WebhookUrl)
+		channels["slack"] = []interface{}{map[string]interface{}{
+			"webhook_url":        value,
+			"webhook_url_secret": secret,
+			"user_groups":        userGroups,
+		}}
+	case "Email":
+		channels["email"] = []interface{}{map[string]interface{}{
+			"recipients":  spec.Recipients,
+			"user_groups": userGroups,
+		}}
+	case "PagerDuty":
+		secret, value := splitSecretExpression(spec.IntegrationKey)
+		channels["pagerduty"] = []interface{}{map[string]interface{}{
+			"integration_key":        value,
+			"integration_key_secret": secret,
+			"user_groups":            userGroups,
+		}}
+	case "MsTeams":
+		var keys, secrets []string
+		for _, key := range spec.MsTeamKeys {
+			if secret, value := splitSecretExpression(key); secret != "" {
+				secrets = append(secrets, secret)
+			} else {
+				keys = append(keys, value)
+			}
+		}
+		channels["msteams"] = []interface{}{map[string]interface{}{
+			"keys":        keys,
+			"key_secrets": secrets,
+			"user_groups": userGroups,
+		}}
+	case "Webhook":
+		secret, value := splitSecretExpression(spec.WebhookUrl)
+		channels["webhook"] = []interface{}{map[string]interface{}{
+			"url":        value,
+			"url_secret": secret,
+		}}
+	}
+	for _, channel := range notificationChannels {
+		d.Set(channel, channels[channel])
+	}
+}
+
+// notificationChannelConfig returns the attributes of the block of a
+// channel, which are nil for an empty block such as `email {}`.
+func notificationChannelConfig(d *schema.ResourceData, channel string) (map[string]interface{}, bool) {
+	configs := d.Get(channel).([]interface{})
+	if len(configs) == 0 {
+		return nil, false
+	}
+	config, _ := configs[0].(map[string]interface{})
+	return config, true
+}
+
+func configString(config map[string]interface{}, key string) string {
+	value, _ := config[key].(string)
+	return value
+}
+
+func configList(config map[string]interface{}, key string) []string {
+	values, _ := config[key].([]interface{})
+	return helpers.ExpandField(values)
+}
+
+func expandUserGroups(config map[string]interface{}) *[]string {
+	userGroups := configList(config, "user_groups")
+	if userGroups == nil {
+		userGroups = []string{}
+	}
+	return &userGroups
+}
+
+func isStageEvent(eventType string) bool {
+	return eventType == "StageStart" || eventType == "StageSuccess" || eventType == "StageFailed"
+}
+
+func secretExpression(secret string) string {
+	return fmt.Sprintf(`<+secrets.getValue("%s")>`, secret)
+}
+
+// secretOrValue returns the expression reading the secret if one is given,
+// and the plain value otherwise.
+func secretOrValue(secret string, value string) string {
+	if secret != "" {
+		return secretExpression(secret)
+	}
+	return value
+}
+
+// splitSecretExpression returns the secret read by a value set to a secret
+// expression, or the value itself.
+func splitSecretExpression(value string) (string, string) {
+	if m := secretExpressionPattern.FindStringSubmatch(value); m != nil {
+		return m[1], ""
+	}
+	return "", value
+}
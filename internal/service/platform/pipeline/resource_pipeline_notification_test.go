@@ -0,0 +1,185 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourcePipelineNotification(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	resourceName := "harness_platform_pipeline_notification.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineNotification(id, name, "echo hello", `
+		slack {
+			webhook_url_secret = "account.slack_webhook"
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "failures"),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_events.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_events.1.for_stages.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "slack.0.webhook_url_secret", "account.slack_webhook"),
+					resource.TestMatchResourceAttr("data.harness_platform_pipeline.test", "yaml", regexp.MustCompile(`(?s)identifier: greet.*notificationRules:\n\s+- name: failures`)),
+				),
+			},
+			{
+				// The pipeline is updated along with the rule, and keeps it.
+				Config: testAccResourcePipelineNotification(id, name, "echo bye", `
+		email {
+			recipients = ["oncall@example.com"]
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "failures"),
+					resource.TestCheckResourceAttr(resourceName, "slack.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "email.0.recipients.0", "oncall@example.com"),
+					resource.TestMatchResourceAttr("data.harness_platform_pipeline.test", "yaml", regexp.MustCompile(`(?s)script: echo bye.*notificationRules:\n\s+- name: failures.*type: Email`)),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       acctest.PipelineResourceImportStateIdFunc(resourceName),
+				ImportStateVerifyIgnore: []string{"commit_message"},
+			},
+		},
+	})
+}
+
+func TestAccResourcePipelineNotification_Remote(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	updatedName := fmt.Sprintf("%s_updated", id)
+	pipelineName := "harness_platform_pipeline.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccPipelineDestroy(pipelineName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineNotificationRemote(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("harness_platform_pipeline_notification.test", "id", "failures"),
+					resource.TestCheckResourceAttrSet(pipelineName, "git_details.0.last_yaml_hash"),
+				),
+			},
+			{
+				// The rule was committed on top of the file Terraform wrote
+				// for the pipeline, which is not a change made in Git.
+				Config: testAccResourcePipelineNotificationRemote(id, updatedName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(pipelineName, "name", updatedName),
+					resource.TestMatchResourceAttr(pipelineName, "yaml", regexp.MustCompile(`notificationRules:\n\s+- name: failures`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePipelineNotificationRemote(id string, name string) string {
+	return strings.Replace(testAccResourcePipeline(id, name), `git_details {`, `preserve_notification_rules = true
+                        git_details {`, 1) + `
+		resource "harness_platform_pipeline_notification" "test" {
+			identifier = "failures"
+			name = "failures"
+			org_id = harness_platform_pipeline.test.org_id
+			project_id = harness_platform_pipeline.test.project_id
+			pipeline_id = harness_platform_pipeline.test.id
+			branch = "main"
+
+			pipeline_events {
+				type = "PipelineFailed"
+			}
+			email {
+				recipients = ["oncall@example.com"]
+			}
+		}
+		`
+}
+
+func testAccResourcePipelineNotification(id string, name string, script string, channel string) string {
+	return fmt.Sprintf(`
+	resource "harness_platform_organization" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+	}
+
+	resource "harness_platform_project" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_organization.test.id
+		color = "#472848"
+	}
+
+	resource "harness_platform_pipeline" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		yaml = <<-EOT
+		pipeline:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      stages:
+        - stage:
+            name: greet
+            identifier: greet
+            type: Custom
+            spec:
+              execution:
+                steps:
+                  - step:
+                      name: hello
+                      identifier: hello
+                      type: ShellScript
+                      timeout: 10m
+                      spec:
+                        shell: Bash
+                        onDelegate: true
+                        source:
+                          type: Inline
+                          spec:
+                            script: %[3]s
+      EOT
+		preserve_notification_rules = true
+	}
+
+	resource "harness_platform_pipeline_notification" "test" {
+		identifier = "failures"
+		name = "failures"
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		pipeline_id = harness_platform_pipeline.test.id
+
+		pipeline_events {
+			type = "PipelineFailed"
+		}
+		pipeline_events {
+			type = "StageFailed"
+		}
+		%[4]s
+	}
+
+	data "harness_platform_pipeline" "test" {
+		identifier = harness_platform_pipeline.test.id
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+		depends_on = [harness_platform_pipeline_notification.test]
+	}
+	`, id, name, script, channel)
+}
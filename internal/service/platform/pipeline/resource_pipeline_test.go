@@ -2,6 +2,8 @@ package pipeline_test
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/antihax/optional"
@@ -45,7 +47,7 @@ func TestAccResourcePipeline(t *testing.T) {
 				ImportState:             true,
 				ImportStateVerify:       true,
 				ImportStateIdFunc:       acctest.ProjectResourceImportStateIdFunc(resourceName),
-				ImportStateVerifyIgnore: []string{"git_details.0.commit_message", "git_details.0.connector_ref", "git_details.0.store_type"},
+				ImportStateVerifyIgnore: []string{"git_details.0.commit_message", "git_details.0.connector_ref", "git_details.0.store_type", "validate_remotely"},
 			},
 		},
 	})
@@ -78,10 +80,11 @@ func TestAccResourcePipelineInline(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateIdFunc: acctest.ProjectResourceImportStateIdFunc(resourceName),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       acctest.ProjectResourceImportStateIdFunc(resourceName),
+				ImportStateVerifyIgnore: []string{"validate_remotely"},
 			},
 		},
 	})
@@ -120,6 +123,160 @@ func TestAccResourcePipeline_DeleteUnderlyingResource(t *testing.T) {
 	})
 }
 
+func TestAccResourcePipeline_InvalidYaml(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "harness_platform_pipeline" "test" {
+						identifier = "%[1]s"
+						org_id = "default"
+						project_id = "default"
+						name = "%[1]s"
+						yaml = <<-EOT
+							stages: []
+						EOT
+					}
+				`, id),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid pipeline YAML"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePipeline_RemoteConflict(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	updatedName := fmt.Sprintf("%s_updated", id)
+
+	resourceName := "harness_platform_pipeline.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccPipelineDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineAcknowledged(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttrSet(resourceName, "git_details.0.last_commit_id"),
+				),
+			},
+			{
+				PreConfig:   func() { testAccPushRemotePipelineChange(t, id) },
+				Config:      testAccResourcePipelineAcknowledged(id, updatedName),
+				ExpectError: regexp.MustCompile("was changed in Git"),
+			},
+			{
+				// Acknowledging the commit pushed to Git lets the update
+				// overwrite it.
+				PreConfig: func() {
+					t.Setenv("TF_VAR_acknowledged_commit_id", testAccRemotePipelineVersion(t, id))
+				},
+				Config: testAccResourcePipelineAcknowledged(id, updatedName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+					resource.TestCheckResourceAttrSet(resourceName, "git_details.0.acknowledged_commit_id"),
+				),
+			},
+			{
+				// The acknowledged commit is left in the configuration, and
+				// later updates are made against what Terraform wrote.
+				Config: testAccResourcePipelineAcknowledged(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+				),
+			},
+		},
+	})
+}
+
+// testAccPushRemotePipelineChange commits a change to the file of a remote
+// pipeline the way an edit made in Git would.
+func testAccPushRemotePipelineChange(t *testing.T, id string) {
+	c, ctx := acctest.TestAccGetClientWithContext()
+	resp, _, err := c.PipelinesApi.GetPipeline(ctx, id, id, id, &nextgen.PipelinesApiGetPipelineOpts{
+		HarnessAccount: optional.NewString(c.AccountId),
+		BranchName:     optional.NewString("main"),
+	})
+	require.NoError(t, err)
+
+	_, _, err = c.PipelinesApi.UpdatePipeline(ctx, nextgen.PipelineUpdateRequestBody{
+		Identifier:   id,
+		Name:         resp.Name,
+		PipelineYaml: strings.Replace(resp.PipelineYaml, "allowStageExecutions: false", "allowStageExecutions: true", 1),
+		GitDetails: &nextgen.GitUpdateDetails{
+			BranchName:    "main",
+			CommitMessage: "Change made outside of Terraform",
+			LastCommitId:  resp.GitDetails.CommitId,
+			LastObjectId:  resp.GitDetails.ObjectId,
+		},
+	}, id, id, id, &nextgen.PipelinesApiUpdatePipelineOpts{
+		HarnessAccount: optional.NewString(c.AccountId),
+	})
+	require.NoError(t, err)
+}
+
+func testAccRemotePipelineVersion(t *testing.T, id string) string {
+	c, ctx := acctest.TestAccGetClientWithContext()
+	resp, _, err := c.PipelinesApi.GetPipeline(ctx, id, id, id, &nextgen.PipelinesApiGetPipelineOpts{
+		HarnessAccount: optional.NewString(c.AccountId),
+		BranchName:     optional.NewString("main"),
+	})
+	require.NoError(t, err)
+	if resp.GitDetails.CommitId != "" {
+		return resp.GitDetails.CommitId
+	}
+	return resp.GitDetails.ObjectId
+}
+
+func testAccResourcePipelineAcknowledged(id string, name string) string {
+	return `
+		variable "acknowledged_commit_id" {
+			type = string
+			default = ""
+		}
+` + strings.Replace(testAccResourcePipeline(id, name), `repo_name = "jajoo_git"`, `repo_name = "jajoo_git"
+                            acknowledged_commit_id = var.acknowledged_commit_id`, 1)
+}
+
+func TestAccResourcePipeline_RuntimeInputsTemplate(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resourceName := "harness_platform_pipeline.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccPipelineDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineRuntimeInputs(id, name, "5m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestMatchResourceAttr(resourceName, "runtime_inputs_template", regexp.MustCompile(`name: greeting\n\s+type: String\n\s+value: <\+input>`)),
+				),
+			},
+			{
+				// The project now exists, so the changed YAML is validated
+				// by Harness during plan, which is on by default.
+				Config: testAccResourcePipelineRuntimeInputs(id, name, "<+input>"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "validate_remotely", "true"),
+					resource.TestMatchResourceAttr(resourceName, "runtime_inputs_template", regexp.MustCompile(`identifier: echo\n\s+type: ShellScript\n\s+timeout: <\+input>`)),
+				),
+			},
+		},
+	})
+}
+
 func testAccGetPipeline(resourceName string, state *terraform.State) (*openapi_client_nextgen.PipelineGetResponseBody, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
 	c, ctx := acctest.TestAccGetClientWithContext()
@@ -369,3 +526,56 @@ func testAccResourcePipelineInline(id string, name string) string {
         }
         `, id, name)
 }
+
+func testAccResourcePipelineRuntimeInputs(id string, name string, timeout string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+		}
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_organization.test.id
+		}
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[2]s"
+			yaml = <<-EOT
+				pipeline:
+				  name: %[2]s
+				  identifier: %[1]s
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  orgIdentifier: ${harness_platform_project.test.org_id}
+				  variables:
+				    - name: greeting
+				      type: String
+				      value: <+input>
+				  stages:
+				    - stage:
+				        name: custom
+				        identifier: custom
+				        type: Custom
+				        spec:
+				          execution:
+				            steps:
+				              - step:
+				                  name: echo
+				                  identifier: echo
+				                  type: ShellScript
+				                  timeout: %[3]s
+				                  spec:
+				                    shell: Bash
+				                    onDelegate: true
+				                    source:
+				                      type: Inline
+				                      spec:
+				                        script: echo <+pipeline.variables.greeting>
+				                    environmentVariables: []
+				                    outputVariables: []
+			EOT
+		}
+	`, id, name, timeout)
+}
@@ -0,0 +1,303 @@
+// Package pipeline_schema provides data sources that render pipeline YAML
+// from typed Terraform blocks. The fields of each data source are generated
+// from the Harness pipeline JSON schema by tools/schemagen with `make
+// pipeline-schema`, into zz_generated_data_sources.go. They are registered in
+// the provider once generated from a pinned copy of the published schema.
+package pipeline_schema
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is how a field is represented in Terraform and rendered in YAML.
+type Kind int
+
+const (
+	// KindString is a string, quoted in YAML when needed.
+	KindString Kind = iota
+	// KindScalar is a boolean or number that also accepts expressions such
+	// as `<+input>`, so it is a string in Terraform, rendered as a boolean or
+	// number when it is one.
+	KindScalar
+	KindBool
+	KindInt
+	KindNumber
+	// KindObject is a nested block.
+	KindObject
+	// KindMap is a map of strings.
+	KindMap
+	// KindYaml is a YAML document embedded as is, such as the output of
+	// another of these data sources.
+	KindYaml
+)
+
+// Field describes a YAML field and the Terraform attribute it is set from.
+type Field struct {
+	Name        string
+	Key         string
+	Kind        Kind
+	Description string
+	Required    bool
+	List        bool
+	Enum        []string
+	// Const is always rendered as the value of the field, which is not
+	// exposed in Terraform.
+	Const  string
+	Fields []Field
+}
+
+// NewYamlDataSource returns a data source that renders the fields as a YAML
+// document under root, as in `step:` or `pipeline:`.
+func NewYamlDataSource(description string, root string, fields []Field) *schema.Resource {
+	s := fieldsSchema(fields)
+	s["yaml"] = &schema.Schema{
+		Description: "Rendered YAML.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: description,
+
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			// The raw configuration tells fields set to false or 0 apart from
+			// fields that are not set.
+			node, err := renderObject(fields, d.GetRawConfig())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			doc := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: root},
+				node,
+			}}
+
+			var buf bytes.Buffer
+			encoder := yaml.NewEncoder(&buf)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(doc); err != nil {
+				return diag.FromErr(err)
+			}
+
+			d.SetId(fmt.Sprintf("%x", sha256.Sum256(buf.Bytes())))
+			d.Set("yaml", buf.String())
+			return nil
+		},
+
+		Schema: s,
+	}
+}
+
+func fieldsSchema(fields []Field) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for _, f := range fields {
+		if f.Const != "" {
+			continue
+		}
+		s[f.Name] = fieldSchema(f)
+	}
+	return s
+}
+
+func fieldSchema(f Field) *schema.Schema {
+	s := &schema.Schema{
+		Description: f.Description,
+		Required:    f.Required,
+		Optional:    !f.Required,
+	}
+
+	var elem *schema.Schema
+	switch f.Kind {
+	case KindBool:
+		elem = &schema.Schema{Type: schema.TypeBool}
+	case KindInt:
+		elem = &schema.Schema{Type: schema.TypeInt}
+	case KindNumber:
+		elem = &schema.Schema{Type: schema.TypeFloat}
+	case KindMap:
+		s.Type = schema.TypeMap
+		s.Elem = &schema.Schema{Type: schema.TypeString}
+		return s
+	case KindObject:
+		s.Type = schema.TypeList
+		s.Elem = &schema.Resource{Schema: fieldsSchema(f.Fields)}
+		if !f.List {
+			s.MaxItems = 1
+		}
+		return s
+	default:
+		elem = &schema.Schema{Type: schema.TypeString}
+		if f.Kind == KindString && len(f.Enum) > 0 {
+			elem.ValidateFunc = validation.StringInSlice(f.Enum, false)
+		}
+	}
+
+	if f.List {
+		s.Type = schema.TypeList
+		s.Elem = elem
+		return s
+	}
+	s.Type = elem.Type
+	s.ValidateFunc = elem.ValidateFunc
+	return s
+}
+
+// renderObject renders the fields set in config, an object of the
+// configuration of the data source or of one of its blocks.
+func renderObject(fields []Field, config cty.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range fields {
+		var value *yaml.Node
+		if f.Const != "" {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.Const}
+		} else {
+			var err error
+			if value, err = renderField(f, configAttr(config, f.Name)); err != nil {
+				return nil, err
+			}
+		}
+		if value != nil {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.Key}, value)
+		}
+	}
+	return node, nil
+}
+
+func configAttr(config cty.Value, name string) cty.Value {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return config.GetAttr(name)
+}
+
+// renderField returns the YAML of a field, or nil if it is not set.
+func renderField(f Field, value cty.Value) (*yaml.Node, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.IsWhollyKnown() {
+		return nil, fmt.Errorf("%s is not known", f.Name)
+	}
+
+	if f.Kind == KindObject {
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		for it := value.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			item, err := renderObject(f.Fields, block)
+			if err != nil {
+				return nil, err
+			}
+			seq.Content = append(seq.Content, item)
+		}
+		if len(seq.Content) == 0 {
+			return nil, nil
+		}
+		if !f.List {
+			return seq.Content[0], nil
+		}
+		return seq, nil
+	}
+
+	if f.Kind == KindMap {
+		m := map[string]string{}
+		for key, v := range value.AsValueMap() {
+			if !v.IsNull() {
+				m[key] = v.AsString()
+			}
+		}
+		var node yaml.Node
+		if err := node.Encode(m); err != nil {
+			return nil, err
+		}
+		return &node, nil
+	}
+
+	if f.List {
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		for it := value.ElementIterator(); it.Next(); {
+			_, item := it.Element()
+			n, err := renderScalar(f, item)
+			if err != nil {
+				return nil, err
+			}
+			if n != nil {
+				seq.Content = append(seq.Content, n)
+			}
+		}
+		return seq, nil
+	}
+	return renderScalar(f, value)
+}
+
+// renderScalar returns the YAML of a single value, tagged with the type it
+// has in the pipeline, or nil if it is null.
+func renderScalar(f Field, value cty.Value) (*yaml.Node, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch value.Type() {
+	case cty.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value.True())}, nil
+	case cty.Number:
+		n := value.AsBigFloat()
+		if f.Kind == KindInt && !n.IsInt() {
+			return nil, fmt.Errorf("%s must be a whole number", f.Name)
+		}
+		text := n.Text('f', -1)
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: scalarTag(text), Value: text}, nil
+	case cty.String:
+		v := value.AsString()
+		switch f.Kind {
+		case KindYaml:
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(v), &doc); err != nil {
+				return nil, fmt.Errorf("invalid YAML in %s: %w", f.Name, err)
+			}
+			if len(doc.Content) == 0 {
+				return nil, nil
+			}
+			return doc.Content[0], nil
+		case KindScalar:
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: scalarTag(v), Value: v}, nil
+		}
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+		if strings.Contains(v, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node, nil
+	}
+	return nil, fmt.Errorf("%s has unexpected type %s", f.Name, value.Type().FriendlyName())
+}
+
+var (
+	intPattern   = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	floatPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+)
+
+// scalarTag returns the tag of a value given as a string for a boolean or
+// number field that also accepts expressions. Only plain booleans and numbers
+// keep their type; anything else, such as `<+input>`, `yes` or `null`, stays
+// a string instead of being resolved by the YAML rules.
+func scalarTag(v string) string {
+	switch {
+	case v == "true" || v == "false":
+		return "!!bool"
+	case intPattern.MatchString(v):
+		return "!!int"
+	case floatPattern.MatchString(v):
+		return "!!float"
+	}
+	return "!!str"
+}
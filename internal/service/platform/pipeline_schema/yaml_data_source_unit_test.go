@@ -0,0 +1,131 @@
+package pipeline_schema
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderScalar(t *testing.T) {
+	for name, test := range map[string]struct {
+		field Field
+		value cty.Value
+		tag   string
+		text  string
+	}{
+		"string":               {Field{Kind: KindString}, cty.StringVal("hello"), "!!str", "hello"},
+		"string like bool":     {Field{Kind: KindString}, cty.StringVal("true"), "!!str", "true"},
+		"empty string":         {Field{Kind: KindString}, cty.StringVal(""), "!!str", ""},
+		"false":                {Field{Kind: KindBool}, cty.False, "!!bool", "false"},
+		"zero":                 {Field{Kind: KindInt}, cty.NumberIntVal(0), "!!int", "0"},
+		"fraction":             {Field{Kind: KindNumber}, cty.NumberFloatVal(0.5), "!!float", "0.5"},
+		"whole number":         {Field{Kind: KindNumber}, cty.NumberIntVal(3), "!!int", "3"},
+		"scalar bool":          {Field{Kind: KindScalar}, cty.StringVal("false"), "!!bool", "false"},
+		"scalar int":           {Field{Kind: KindScalar}, cty.StringVal("10"), "!!int", "10"},
+		"scalar exponent":      {Field{Kind: KindScalar}, cty.StringVal("1e3"), "!!float", "1e3"},
+		"scalar expression":    {Field{Kind: KindScalar}, cty.StringVal("<+input>"), "!!str", "<+input>"},
+		"scalar yes":           {Field{Kind: KindScalar}, cty.StringVal("yes"), "!!str", "yes"},
+		"scalar null":          {Field{Kind: KindScalar}, cty.StringVal("null"), "!!str", "null"},
+		"scalar leading zero":  {Field{Kind: KindScalar}, cty.StringVal("010"), "!!str", "010"},
+		"scalar special float": {Field{Kind: KindScalar}, cty.StringVal(".inf"), "!!str", ".inf"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			node, err := renderScalar(test.field, test.value)
+			require.NoError(t, err)
+			require.Equal(t, test.tag, node.Tag)
+			require.Equal(t, test.text, node.Value)
+
+			// The rendered value decodes back with the same type.
+			out, err := yaml.Marshal(node)
+			require.NoError(t, err)
+			var decoded yaml.Node
+			require.NoError(t, yaml.Unmarshal(out, &decoded))
+			require.Equal(t, test.tag, decoded.Content[0].ShortTag())
+		})
+	}
+
+	node, err := renderScalar(Field{Kind: KindString}, cty.NullVal(cty.String))
+	require.NoError(t, err)
+	require.Nil(t, node)
+
+	_, err = renderScalar(Field{Name: "count", Kind: KindInt}, cty.NumberFloatVal(1.5))
+	require.Error(t, err)
+}
+
+func TestRenderObject(t *testing.T) {
+	fields := []Field{
+		{Name: "type", Key: "type", Kind: KindString, Const: "ShellScript"},
+		{Name: "name", Key: "name", Kind: KindString},
+		{Name: "description", Key: "description", Kind: KindString},
+		{Name: "fail_fast", Key: "failFast", Kind: KindBool},
+		{Name: "retries", Key: "retries", Kind: KindInt},
+		{Name: "delegate_selectors", Key: "delegateSelectors", Kind: KindString, List: true},
+		{Name: "tags", Key: "tags", Kind: KindMap},
+		{Name: "steps", Key: "steps", Kind: KindYaml, List: true},
+		{Name: "spec", Key: "spec", Kind: KindObject, Fields: []Field{
+			{Name: "on_delegate", Key: "onDelegate", Kind: KindScalar},
+			{Name: "script", Key: "script", Kind: KindString},
+		}},
+	}
+	blockType := cty.Object(map[string]cty.Type{
+		"on_delegate": cty.String,
+		"script":      cty.String,
+	})
+
+	node, err := renderObject(fields, cty.ObjectVal(map[string]cty.Value{
+		"name":               cty.StringVal("hello"),
+		"description":        cty.NullVal(cty.String),
+		"fail_fast":          cty.False,
+		"retries":            cty.NumberIntVal(0),
+		"delegate_selectors": cty.ListValEmpty(cty.String),
+		"tags":               cty.MapVal(map[string]cty.Value{"team": cty.StringVal("platform")}),
+		"steps":              cty.ListVal([]cty.Value{cty.StringVal("step:\n  identifier: echo\n")}),
+		"spec": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"on_delegate": cty.StringVal("true"),
+			"script":      cty.StringVal("echo hello\necho bye"),
+		})}),
+	}))
+	require.NoError(t, err)
+
+	out, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	require.Equal(t, `type: ShellScript
+name: hello
+failFast: false
+retries: 0
+delegateSelectors: []
+tags:
+    team: platform
+steps:
+    - step:
+        identifier: echo
+spec:
+    onDelegate: true
+    script: |-
+        echo hello
+        echo bye
+`, string(out))
+
+	node, err = renderObject(fields, cty.ObjectVal(map[string]cty.Value{
+		"name":               cty.StringVal("hello"),
+		"description":        cty.NullVal(cty.String),
+		"fail_fast":          cty.NullVal(cty.Bool),
+		"retries":            cty.NullVal(cty.Number),
+		"delegate_selectors": cty.NullVal(cty.List(cty.String)),
+		"tags":               cty.NullVal(cty.Map(cty.String)),
+		"steps":              cty.NullVal(cty.List(cty.String)),
+		"spec":               cty.ListValEmpty(blockType),
+	}))
+	require.NoError(t, err)
+
+	out, err = yaml.Marshal(node)
+	require.NoError(t, err)
+	require.Equal(t, "type: ShellScript\nname: hello\n", string(out))
+
+	_, err = renderObject(fields, cty.ObjectVal(map[string]cty.Value{
+		"steps": cty.ListVal([]cty.Value{cty.StringVal("step: [")}),
+	}))
+	require.Error(t, err)
+}
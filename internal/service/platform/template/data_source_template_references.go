@@ -0,0 +1,251 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const pipelineListPageSize = 100
+
+func DataSourceTemplateReferences() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for finding the pipelines and templates that use a Template, and at which version. The YAML of the pipelines and templates of a single scope is scanned, instead of calling the entity setup usage API, which the Harness Go SDK does not expose, so scopes below the searched one are not covered. Entities whose YAML cannot be parsed are skipped with a warning.",
+
+		ReadContext: dataSourceTemplateReferencesRead,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the template.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"org_id": {
+				Description: "Organization identifier of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"version_label": {
+				Description: "Only return references that resolve to this version of the template. References to the stable version match the current stable version.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"consumer_org_id": {
+				Description: "Organization identifier of the scope to search. Defaults to the organization of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"consumer_project_id": {
+				Description: "Project identifier of the scope to search. Defaults to the project of the template. Pipelines are only searched when this is set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"references": {
+				Description: "Entities that use the template.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "Type of the entity using the template. Either `PIPELINE` or `TEMPLATE`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"identifier": {
+							Description: "Identifier of the entity using the template.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"org_id": {
+							Description: "Organization identifier of the entity using the template.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"project_id": {
+							Description: "Project identifier of the entity using the template.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"entity_version_label": {
+							Description: "Version label of the template using the template. Empty for pipelines.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version_label": {
+							Description: "Version of the template that is used.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"stable": {
+							Description: "Whether the entity uses whichever version is stable rather than a fixed version.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourceTemplateReferencesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+	id := d.Get("identifier").(string)
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+
+	consumerOrgId := orgId
+	if attr, ok := d.GetOk("consumer_org_id"); ok {
+		consumerOrgId = attr.(string)
+	}
+	consumerProjectId := projectId
+	if attr, ok := d.GetOk("consumer_project_id"); ok {
+		consumerProjectId = attr.(string)
+	}
+	if (orgId != "" && orgId != consumerOrgId) || (projectId != "" && projectId != consumerProjectId) {
+		return diag.Errorf("template %s can only be used from its own scope or the scopes below it", id)
+	}
+	templateRef := scopedTemplateRef(id, orgId, projectId, consumerOrgId, consumerProjectId)
+
+	versions, httpResp, err := listTemplateVersions(ctx, c, id, orgId, projectId)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if len(versions) == 0 {
+		return diag.Errorf("template %s not found", id)
+	}
+	var stableVersion string
+	for _, version := range versions {
+		if version.StableTemplate {
+			stableVersion = version.VersionLabel
+		}
+	}
+
+	var diags diag.Diagnostics
+	references := []interface{}{}
+	addReferences := func(text string, reference map[string]interface{}) {
+		refs, err := helpers.TemplateReferences(text)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Skipped %s %s with invalid YAML", reference["type"], reference["identifier"]),
+				Detail:   err.Error(),
+			})
+			return
+		}
+		for _, ref := range refs {
+			if ref.TemplateRef != templateRef {
+				continue
+			}
+			version := ref.VersionLabel
+			if version == "" {
+				version = stableVersion
+			}
+			if attr, ok := d.GetOk("version_label"); ok && attr.(string) != version {
+				continue
+			}
+			r := map[string]interface{}{}
+			for k, v := range reference {
+				r[k] = v
+			}
+			r["version_label"] = version
+			r["stable"] = ref.VersionLabel == ""
+			references = append(references, r)
+		}
+	}
+
+	if consumerProjectId != "" {
+		pipelines, httpResp, err := listPipelines(ctx, c, consumerOrgId, consumerProjectId)
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		for _, pipeline := range pipelines {
+			resp, httpResp, err := c.PipelinesApi.GetPipeline(ctx, consumerOrgId, consumerProjectId, pipeline.Identifier, &nextgen.PipelinesApiGetPipelineOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+			})
+			if err != nil {
+				return helpers.HandleApiError(err, d, httpResp)
+			}
+			addReferences(resp.PipelineYaml, map[string]interface{}{
+				"type":                 "PIPELINE",
+				"identifier":           pipeline.Identifier,
+				"org_id":               consumerOrgId,
+				"project_id":           consumerProjectId,
+				"entity_version_label": "",
+			})
+		}
+	}
+
+	templates, httpResp, err := listTemplates(ctx, c, consumerOrgId, consumerProjectId, optional.EmptyInterface())
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	for _, template := range templates {
+		identifier := template.Identifier
+		if identifier == "" {
+			identifier = template.Slug
+		}
+		resp, httpResp, err := getTemplateVersion(ctx, c, identifier, consumerOrgId, consumerProjectId, template.VersionLabel)
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		addReferences(resp.Template.Yaml, map[string]interface{}{
+			"type":                 "TEMPLATE",
+			"identifier":           identifier,
+			"org_id":               consumerOrgId,
+			"project_id":           consumerProjectId,
+			"entity_version_label": template.VersionLabel,
+		})
+	}
+
+	d.SetId(id)
+	d.Set("references", references)
+	return diags
+}
+
+// scopedTemplateRef returns how entities of the consumer scope refer to the
+// template: templates of a higher scope are prefixed with that scope.
+func scopedTemplateRef(id string, orgId string, projectId string, consumerOrgId string, consumerProjectId string) string {
+	switch {
+	case projectId != "":
+		return id
+	case orgId != "" && consumerProjectId != "":
+		return "org." + id
+	case orgId == "" && consumerOrgId != "":
+		return "account." + id
+	}
+	return id
+}
+
+func listPipelines(ctx context.Context, c *nextgen.APIClient, orgId string, projectId string) ([]nextgen.PipelineListResponseBody, *http.Response, error) {
+	var pipelines []nextgen.PipelineListResponseBody
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.PipelinesApi.ListPipelines(ctx, orgId, projectId, &nextgen.PipelinesApiListPipelinesOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+			Page:           optional.NewInt32(page),
+			Limit:          optional.NewInt32(pipelineListPageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+
+		pipelines = append(pipelines, resp...)
+		if len(resp) < pipelineListPageSize {
+			return pipelines, httpResp, nil
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package template_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTemplateReferences(t *testing.T) {
+
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	resourceName := "data.harness_platform_template_references.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTemplateReferences(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "references.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "references.0.type", "PIPELINE"),
+					resource.TestCheckResourceAttr(resourceName, "references.0.identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "references.0.version_label", "v1"),
+					resource.TestCheckResourceAttr(resourceName, "references.0.stable", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceTemplateReferences(id string, name string) string {
+	return fmt.Sprintf(`
+	resource "harness_platform_organization" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+	}
+
+	resource "harness_platform_project" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_organization.test.id
+		color = "#472848"
+	}
+
+	resource "harness_platform_template" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		version = "v1"
+		is_stable = true
+		template_yaml = <<-EOT
+		template:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      versionLabel: v1
+      type: Step
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      spec:
+        type: ShellScript
+        timeout: 10m
+        spec:
+          shell: Bash
+          onDelegate: true
+          source:
+            type: Inline
+            spec:
+              script: echo hello
+          environmentVariables: []
+          outputVariables: []
+      EOT
+	}
+
+	resource "harness_platform_pipeline" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		yaml = <<-EOT
+		pipeline:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      stages:
+        - stage:
+            name: build
+            identifier: build
+            type: Custom
+            spec:
+              execution:
+                steps:
+                  - step:
+                      name: hello
+                      identifier: hello
+                      template:
+                        templateRef: ${harness_platform_template.test.id}
+                        versionLabel: v1
+      EOT
+	}
+
+	data "harness_platform_template_references" "test" {
+		identifier = harness_platform_template.test.id
+		org_id = harness_platform_pipeline.test.org_id
+		project_id = harness_platform_pipeline.test.project_id
+	}
+	`, id, name)
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/antihax/optional"
@@ -21,6 +22,7 @@ func ResourceTemplate() *schema.Resource {
 		UpdateContext: resourceTemplateCreateOrUpdate,
 		DeleteContext: resourceTemplateDelete,
 		CreateContext: resourceTemplateCreateOrUpdate,
+		CustomizeDiff: resourceTemplateCustomizeDiff,
 		Importer:      helpers.MultiLevelResourceImporter,
 
 		Schema: map[string]*schema.Schema{
@@ -554,3 +556,14 @@ func readGitDetails(template nextgen.TemplateWithInputsResponse, store_type opti
 	}
 	return git_details
 }
+
+// resourceTemplateCustomizeDiff rejects broken template YAML during plan.
+func resourceTemplateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("template_yaml") {
+		return nil
+	}
+	if err := helpers.ValidateEntityYaml(d.Get("template_yaml").(string), "template"); err != nil {
+		return fmt.Errorf("invalid template YAML: %w", err)
+	}
+	return nil
+}
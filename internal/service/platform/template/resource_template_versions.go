@@ -0,0 +1,483 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const templateListPageSize = 100
+
+func ResourceTemplateVersions() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for managing all the versions of a Template, and which of them is stable. Versions removed from the configuration are deleted, unless pipelines or other templates still reference them.",
+
+		ReadContext:   resourceTemplateVersionsRead,
+		CreateContext: resourceTemplateVersionsCreate,
+		UpdateContext: resourceTemplateVersionsUpdate,
+		DeleteContext: resourceTemplateVersionsDelete,
+		CustomizeDiff: resourceTemplateVersionsCustomizeDiff,
+		Importer:      helpers.MultiLevelResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Description: "Identifier of the template.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"org_id": {
+				Description: "Organization identifier of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "Project identifier of the template.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"stable_version": {
+				Description: "Version label of the stable version. Must be one of `versions`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"versions": {
+				Description: "Versions of the template.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				// Versions are identified by their label alone, so a change
+				// to the YAML of a version is an update of that version.
+				Set: func(v interface{}) int {
+					return schema.HashString(v.(map[string]interface{})["version"])
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Description: "Version label of the template. Must match the `versionLabel` of the YAML.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"template_yaml": {
+							Description: "Yaml of this version of the template." + helpers.Descriptions.YamlText.String(),
+							Type:        schema.TypeString,
+							Required:    true,
+							// Harness reformats the YAML it stores.
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return helpers.YamlEqual(old, new)
+							},
+						},
+						"comments": {
+							Description: "Comment recorded with changes to this version.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func resourceTemplateVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+	id := d.Id()
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+
+	summaries, httpResp, err := listTemplateVersions(ctx, c, id, orgId, projectId)
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	if len(summaries) == 0 {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	// Comments are not returned by the API, so keep the configured ones.
+	comments := map[string]interface{}{}
+	for _, v := range d.Get("versions").(*schema.Set).List() {
+		version := v.(map[string]interface{})
+		comments[version["version"].(string)] = version["comments"]
+	}
+
+	versions := []interface{}{}
+	for _, summary := range summaries {
+		resp, httpResp, err := getTemplateVersion(ctx, c, id, orgId, projectId, summary.VersionLabel)
+		if err != nil {
+			return helpers.HandleReadApiError(err, d, httpResp)
+		}
+		versions = append(versions, map[string]interface{}{
+			"version":       summary.VersionLabel,
+			"template_yaml": resp.Template.Yaml,
+			"comments":      comments[summary.VersionLabel],
+		})
+		if summary.StableTemplate {
+			d.Set("stable_version", summary.VersionLabel)
+		}
+	}
+
+	d.Set("identifier", id)
+	d.Set("org_id", orgId)
+	d.Set("project_id", projectId)
+	d.Set("versions", versions)
+
+	return nil
+}
+
+func resourceTemplateVersionsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+	id := d.Get("identifier").(string)
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	stableVersion := d.Get("stable_version").(string)
+	versions := expandTemplateVersions(d.Get("versions").(*schema.Set))
+
+	// The stable version goes first so the template never has another one
+	// marked stable.
+	if httpResp, err := createTemplateVersion(ctx, c, orgId, projectId, versions[stableVersion], true); err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	d.SetId(id)
+
+	for label, version := range versions {
+		if label == stableVersion {
+			continue
+		}
+		if httpResp, err := createTemplateVersion(ctx, c, orgId, projectId, version, false); err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+	}
+
+	return resourceTemplateVersionsRead(ctx, d, meta)
+}
+
+func resourceTemplateVersionsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+	id := d.Id()
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	o, n := d.GetChange("versions")
+	oldVersions := expandTemplateVersions(o.(*schema.Set))
+	newVersions := expandTemplateVersions(n.(*schema.Set))
+
+	for label, version := range newVersions {
+		old, ok := oldVersions[label]
+		if !ok {
+			if httpResp, err := createTemplateVersion(ctx, c, orgId, projectId, version, false); err != nil {
+				return helpers.HandleApiError(err, d, httpResp)
+			}
+			continue
+		}
+		// State holds the YAML as Harness formats it.
+		if !helpers.YamlEqual(old["template_yaml"].(string), version["template_yaml"].(string)) || old["comments"] != version["comments"] {
+			if httpResp, err := updateTemplateVersion(ctx, c, id, orgId, projectId, version); err != nil {
+				return helpers.HandleApiError(err, d, httpResp)
+			}
+		}
+	}
+
+	// Promote before deleting, so a retired version is never the stable one.
+	if d.HasChange("stable_version") {
+		if httpResp, err := setStableTemplateVersion(ctx, c, id, orgId, projectId, d.Get("stable_version").(string)); err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+	}
+
+	for label := range oldVersions {
+		if _, ok := newVersions[label]; ok {
+			continue
+		}
+		if diags := deleteTemplateVersion(ctx, c, d, id, orgId, projectId, label); diags.HasError() {
+			// Record the versions that are left, including the one that
+			// could not be deleted, rather than the configured ones.
+			return append(diags, resourceTemplateVersionsRead(ctx, d, meta)...)
+		}
+	}
+
+	return resourceTemplateVersionsRead(ctx, d, meta)
+}
+
+func resourceTemplateVersionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+	id := d.Id()
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	stableVersion := d.Get("stable_version").(string)
+
+	// The stable version can only be deleted once it is the last one left.
+	for label := range expandTemplateVersions(d.Get("versions").(*schema.Set)) {
+		if label == stableVersion {
+			continue
+		}
+		if diags := deleteTemplateVersion(ctx, c, d, id, orgId, projectId, label); diags.HasError() {
+			return diags
+		}
+	}
+
+	return deleteTemplateVersion(ctx, c, d, id, orgId, projectId, stableVersion)
+}
+
+// resourceTemplateVersionsCustomizeDiff rejects versions whose YAML does not
+// belong to the template, and a stable version that is not declared.
+func resourceTemplateVersionsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("versions") {
+		return nil
+	}
+
+	versions := expandTemplateVersions(d.Get("versions").(*schema.Set))
+	for label, version := range versions {
+		text := version["template_yaml"].(string)
+		if text == "" {
+			// Not known until apply.
+			continue
+		}
+		if err := helpers.ValidateEntityYaml(text, "template"); err != nil {
+			return fmt.Errorf("invalid YAML for version %s: %w", label, err)
+		}
+
+		var doc struct {
+			Template struct {
+				Identifier   string `yaml:"identifier"`
+				VersionLabel string `yaml:"versionLabel"`
+			} `yaml:"template"`
+		}
+		if err := yaml.Unmarshal([]byte(text), &doc); err != nil {
+			return fmt.Errorf("invalid YAML for version %s: %w", label, err)
+		}
+		if doc.Template.VersionLabel != label {
+			return fmt.Errorf("YAML of version %s has versionLabel %q", label, doc.Template.VersionLabel)
+		}
+		if d.NewValueKnown("identifier") && doc.Template.Identifier != d.Get("identifier").(string) {
+			return fmt.Errorf("YAML of version %s has identifier %q, expected %q", label, doc.Template.Identifier, d.Get("identifier").(string))
+		}
+	}
+
+	if d.NewValueKnown("stable_version") {
+		if _, ok := versions[d.Get("stable_version").(string)]; !ok {
+			return fmt.Errorf("stable_version %q is not one of the declared versions", d.Get("stable_version").(string))
+		}
+	}
+	return nil
+}
+
+func expandTemplateVersions(set *schema.Set) map[string]map[string]interface{} {
+	versions := map[string]map[string]interface{}{}
+	for _, v := range set.List() {
+		version := v.(map[string]interface{})
+		versions[version["version"].(string)] = version
+	}
+	return versions
+}
+
+func listTemplateVersions(ctx context.Context, c *nextgen.APIClient, id string, orgId string, projectId string) ([]nextgen.TemplateMetadataSummaryResponse, *http.Response, error) {
+	resp, httpResp, err := listTemplates(ctx, c, orgId, projectId, optional.NewInterface([]string{id}))
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	var versions []nextgen.TemplateMetadataSummaryResponse
+	for _, summary := range resp {
+		if summary.Identifier == id || summary.Slug == id {
+			versions = append(versions, summary)
+		}
+	}
+	return versions, httpResp, nil
+}
+
+// listTemplates lists every version of the templates of a scope, optionally
+// limited to the given identifiers.
+func listTemplates(ctx context.Context, c *nextgen.APIClient, orgId string, projectId string, identifiers optional.Interface) ([]nextgen.TemplateMetadataSummaryResponse, *http.Response, error) {
+	var templates []nextgen.TemplateMetadataSummaryResponse
+	for page := int32(0); ; page++ {
+		var resp []nextgen.TemplateMetadataSummaryResponse
+		var httpResp *http.Response
+		var err error
+
+		if projectId != "" {
+			resp, httpResp, err = c.ProjectTemplateApi.GetTemplatesListProject(ctx, orgId, projectId, &nextgen.ProjectTemplateApiGetTemplatesListProjectOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(templateListPageSize),
+				Type_:          optional.NewString("ALL"),
+				Identifiers:    identifiers,
+			})
+		} else if orgId != "" {
+			resp, httpResp, err = c.OrgTemplateApi.GetTemplatesListOrg(ctx, orgId, &nextgen.OrgTemplateApiGetTemplatesListOrgOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(templateListPageSize),
+				Type_:          optional.NewString("ALL"),
+				Identifiers:    identifiers,
+			})
+		} else {
+			resp, httpResp, err = c.AccountTemplateApi.GetTemplatesListAcc(ctx, &nextgen.AccountTemplateApiGetTemplatesListAccOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(templateListPageSize),
+				Type_:          optional.NewString("ALL"),
+				Identifiers:    identifiers,
+			})
+		}
+		if err != nil {
+			return nil, httpResp, err
+		}
+
+		templates = append(templates, resp...)
+		if len(resp) < templateListPageSize {
+			return templates, httpResp, nil
+		}
+	}
+}
+
+func getTemplateVersion(ctx context.Context, c *nextgen.APIClient, id string, orgId string, projectId string, version string) (nextgen.TemplateWithInputsResponse, *http.Response, error) {
+	if projectId != "" {
+		return c.ProjectTemplateApi.GetTemplateProject(ctx, projectId, id, orgId, version, &nextgen.ProjectTemplateApiGetTemplateProjectOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else if orgId != "" {
+		return c.OrgTemplateApi.GetTemplateOrg(ctx, id, orgId, version, &nextgen.OrgTemplateApiGetTemplateOrgOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	}
+	return c.AccountTemplateApi.GetTemplateAcc(ctx, id, version, &nextgen.AccountTemplateApiGetTemplateAccOpts{
+		HarnessAccount: optional.NewString(c.AccountId),
+	})
+}
+
+func createTemplateVersion(ctx context.Context, c *nextgen.APIClient, orgId string, projectId string, version map[string]interface{}, isStable bool) (*http.Response, error) {
+	template := nextgen.TemplateCreateRequestBody{
+		TemplateYaml: version["template_yaml"].(string),
+		IsStable:     isStable,
+		Comments:     version["comments"].(string),
+	}
+
+	var httpResp *http.Response
+	var err error
+	if projectId != "" {
+		_, httpResp, err = c.ProjectTemplateApi.CreateTemplatesProject(ctx, orgId, projectId, &nextgen.ProjectTemplateApiCreateTemplatesProjectOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else if orgId != "" {
+		_, httpResp, err = c.OrgTemplateApi.CreateTemplatesOrg(ctx, orgId, &nextgen.OrgTemplateApiCreateTemplatesOrgOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else {
+		_, httpResp, err = c.AccountTemplateApi.CreateTemplatesAcc(ctx, &nextgen.AccountTemplateApiCreateTemplatesAccOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	}
+	return httpResp, err
+}
+
+func updateTemplateVersion(ctx context.Context, c *nextgen.APIClient, id string, orgId string, projectId string, version map[string]interface{}) (*http.Response, error) {
+	label := version["version"].(string)
+	template := nextgen.TemplateUpdateRequestBody{
+		TemplateYaml: version["template_yaml"].(string),
+		Comments:     version["comments"].(string),
+	}
+
+	var httpResp *http.Response
+	var err error
+	if projectId != "" {
+		_, httpResp, err = c.ProjectTemplateApi.UpdateTemplateProject(ctx, projectId, id, orgId, label, &nextgen.ProjectTemplateApiUpdateTemplateProjectOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else if orgId != "" {
+		_, httpResp, err = c.OrgTemplateApi.UpdateTemplateOrg(ctx, id, orgId, label, &nextgen.OrgTemplateApiUpdateTemplateOrgOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else {
+		_, httpResp, err = c.AccountTemplateApi.UpdateTemplateAcc(ctx, id, label, &nextgen.AccountTemplateApiUpdateTemplateAccOpts{
+			Body:           optional.NewInterface(template),
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	}
+	return httpResp, err
+}
+
+func setStableTemplateVersion(ctx context.Context, c *nextgen.APIClient, id string, orgId string, projectId string, version string) (*http.Response, error) {
+	var httpResp *http.Response
+	var err error
+	if projectId != "" {
+		_, httpResp, err = c.ProjectTemplateApi.UpdateTemplateStableProject(ctx, orgId, projectId, id, version, &nextgen.ProjectTemplateApiUpdateTemplateStableProjectOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else if orgId != "" {
+		_, httpResp, err = c.OrgTemplateApi.UpdateTemplateStableOrg(ctx, orgId, id, version, &nextgen.OrgTemplateApiUpdateTemplateStableOrgOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else {
+		_, httpResp, err = c.AccountTemplateApi.UpdateTemplateStableAcc(ctx, id, version, &nextgen.AccountTemplateApiUpdateTemplateStableAccOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	}
+	return httpResp, err
+}
+
+// deleteTemplateVersion deletes a single version without forcing, so Harness
+// refuses while pipelines or other templates still reference it.
+func deleteTemplateVersion(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData, id string, orgId string, projectId string, version string) diag.Diagnostics {
+	var httpResp *http.Response
+	var err error
+	if projectId != "" {
+		httpResp, err = c.ProjectTemplateApi.DeleteTemplateProject(ctx, projectId, id, orgId, version, &nextgen.ProjectTemplateApiDeleteTemplateProjectOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+			ForceDelete:    optional.NewBool(false),
+		})
+	} else if orgId != "" {
+		httpResp, err = c.OrgTemplateApi.DeleteTemplateOrg(ctx, id, orgId, version, &nextgen.OrgTemplateApiDeleteTemplateOrgOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+			ForceDelete:    optional.NewBool(false),
+		})
+	} else {
+		httpResp, err = c.AccountTemplateApi.DeleteTemplateAcc(ctx, id, version, &nextgen.AccountTemplateApiDeleteTemplateAccOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+			ForceDelete:    optional.NewBool(false),
+		})
+	}
+	if err == nil || (httpResp != nil && httpResp.StatusCode == http.StatusNotFound) {
+		return nil
+	}
+
+	diags := helpers.HandleApiError(err, d, httpResp)
+	if isEntityReferencedError(err) {
+		for i := range diags {
+			diags[i].Detail = fmt.Sprintf("Version %s of template %s was not deleted. Versions still referenced by pipelines or other templates must be released from them first.", version, id)
+		}
+	}
+	return diags
+}
+
+// isEntityReferencedError reports whether err is the error Harness returns
+// when deleting an entity that other entities still reference.
+func isEntityReferencedError(err error) bool {
+	swaggerErr, ok := err.(nextgen.GenericSwaggerError)
+	if !ok {
+		return false
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	return json.Unmarshal(swaggerErr.Body(), &body) == nil && body.Code == "ENTITY_REFERENCE_EXCEPTION"
+}
@@ -0,0 +1,194 @@
+package template_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	openapi_client_nextgen "github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceTemplateVersions(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resourceName := "harness_platform_template_versions.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccTemplateDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTemplateVersions(id, name, "v1", []string{"v1", "v2"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "stable_version", "v1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceTemplateVersions(id, name, "v2", []string{"v2"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "stable_version", "v2"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "versions.*", map[string]string{
+						"version": "v2",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.ProjectResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccResourceTemplateVersions_ReferencedVersion(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+
+	resourceName := "harness_platform_template_versions.test"
+	config := testAccResourceTemplateVersions(id, name, "v1", []string{"v1", "v2"}) + testAccTemplateVersionsPipeline(id, name, "v2")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "2"),
+				),
+			},
+			{
+				// v2 is still used by the pipeline, so Harness refuses to
+				// delete it.
+				Config:      testAccResourceTemplateVersions(id, name, "v1", []string{"v1"}) + testAccTemplateVersionsPipeline(id, name, "v2"),
+				ExpectError: regexp.MustCompile("Version v2 of template .* was not deleted"),
+			},
+			{
+				// The version that was kept is recorded, so restoring the
+				// configuration plans no changes.
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "2"),
+					testAccTemplateVersionExists(resourceName, "v2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTemplateVersionExists(resourceName string, version string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		r := acctest.TestAccGetResource(resourceName, state)
+		c, ctx := acctest.TestAccGetClientWithContext()
+		_, _, err := c.ProjectTemplateApi.GetTemplateProject(ctx, r.Primary.Attributes["project_id"], r.Primary.ID, r.Primary.Attributes["org_id"], version, &openapi_client_nextgen.ProjectTemplateApiGetTemplateProjectOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+		return err
+	}
+}
+
+func testAccTemplateVersionsPipeline(id string, name string, version string) string {
+	return fmt.Sprintf(`
+	resource "harness_platform_pipeline" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		name = "%[2]s"
+		yaml = <<-EOT
+			pipeline:
+			  name: %[2]s
+			  identifier: %[1]s
+			  projectIdentifier: ${harness_platform_project.test.id}
+			  orgIdentifier: ${harness_platform_project.test.org_id}
+			  stages:
+			    - stage:
+			        name: custom
+			        identifier: custom
+			        type: Custom
+			        spec:
+			          execution:
+			            steps:
+			              - step:
+			                  name: echo
+			                  identifier: echo
+			                  template:
+			                    templateRef: %[1]s
+			                    versionLabel: %[3]s
+		EOT
+
+		depends_on = [harness_platform_template_versions.test]
+	}
+	`, id, name, version)
+}
+
+func testAccResourceTemplateVersions(id string, name string, stableVersion string, versions []string) string {
+	var blocks string
+	for _, version := range versions {
+		blocks += fmt.Sprintf(`
+		versions {
+			version = "%[3]s"
+			template_yaml = <<-EOT
+			template:
+      name: "%[2]s"
+      identifier: "%[1]s"
+      versionLabel: %[3]s
+      type: Step
+      projectIdentifier: ${harness_platform_project.test.id}
+      orgIdentifier: ${harness_platform_project.test.org_id}
+      tags: {}
+      spec:
+        type: ShellScript
+        timeout: 10m
+        spec:
+          shell: Bash
+          onDelegate: true
+          source:
+            type: Inline
+            spec:
+              script: echo %[3]s
+          environmentVariables: []
+          outputVariables: []
+      EOT
+		}
+`, id, name, version)
+	}
+
+	return fmt.Sprintf(`
+	resource "harness_platform_organization" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+	}
+
+	resource "harness_platform_project" "test" {
+		identifier = "%[1]s"
+		name = "%[2]s"
+		org_id = harness_platform_organization.test.id
+		color = "#472848"
+	}
+
+	resource "harness_platform_template_versions" "test" {
+		identifier = "%[1]s"
+		org_id = harness_platform_project.test.org_id
+		project_id = harness_platform_project.test.id
+		stable_version = "%[3]s"
+		%[4]s
+	}
+	`, id, name, stableVersion, blocks)
+}
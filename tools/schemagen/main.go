@@ -0,0 +1,493 @@
+// Command schemagen generates the fields of the pipeline_schema data sources
+// from the Harness pipeline JSON schema.
+//
+// Each target in the targets file names a definition of the schema and the
+// data source it becomes. Properties are mapped as follows:
+//
+//   - strings, booleans and numbers become attributes of the same type, or
+//     string attributes when the schema also accepts an expression such as
+//     `<+input>`, rendered as booleans or numbers when they are plain ones
+//   - objects become nested blocks, and arrays of objects repeated blocks
+//   - objects of string values become maps
+//   - a `type` with a single allowed value is rendered as is and not exposed
+//   - anything else, objects nested deeper than the target's depth and the
+//     paths listed as raw become YAML strings, to be filled with the output
+//     of another data source or yamlencode()
+//
+// Usage, from this module:
+//
+//	go run ./schemagen -schema schemagen/schema/<published schema>.json -targets schemagen/targets.json -out ../internal/service/platform/pipeline_schema/zz_generated_data_sources.go
+//
+// The schema may also be a URL, such as that of the published schema, but the
+// checked-in data sources should be generated from a pinned, vendored copy
+// so they can be regenerated the same way.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const defaultMaxDepth = 4
+
+type target struct {
+	// DataSource is the name of the data source, as registered in the
+	// provider.
+	DataSource string `json:"data_source"`
+	// Func is the name of the generated constructor.
+	Func        string `json:"func"`
+	Description string `json:"description"`
+	// Root is the top level key of the rendered YAML, such as `step`.
+	Root string `json:"root"`
+	// Ref is the JSON pointer of the definition, such as
+	// `#/definitions/pipeline/steps/common/ShellScriptStepNode`.
+	Ref string `json:"ref"`
+	// Raw lists dotted YAML paths, such as `spec.execution.steps`, rendered
+	// from YAML strings rather than blocks.
+	Raw []string `json:"raw"`
+	// Exclude lists dotted YAML paths that are left out.
+	Exclude  []string `json:"exclude"`
+	MaxDepth int      `json:"max_depth"`
+}
+
+type kind string
+
+const (
+	kindString kind = "KindString"
+	kindScalar kind = "KindScalar"
+	kindBool   kind = "KindBool"
+	kindInt    kind = "KindInt"
+	kindNumber kind = "KindNumber"
+	kindObject kind = "KindObject"
+	kindMap    kind = "KindMap"
+	kindYaml   kind = "KindYaml"
+)
+
+type field struct {
+	Name        string
+	Key         string
+	Kind        kind
+	Description string
+	Required    bool
+	List        bool
+	Enum        []string
+	Const       string
+	Fields      []field
+}
+
+type generator struct {
+	root   map[string]interface{}
+	target target
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path or URL of the pipeline JSON schema")
+	targetsPath := flag.String("targets", "", "path of the targets file")
+	out := flag.String("out", "", "path of the generated Go file")
+	pkg := flag.String("package", "pipeline_schema", "package of the generated Go file")
+	flag.Parse()
+
+	if *schemaPath == "" || *targetsPath == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var root map[string]interface{}
+	if err := readJson(*schemaPath, &root); err != nil {
+		log.Fatalf("reading schema: %s", err)
+	}
+	var targets []target
+	if err := readJson(*targetsPath, &targets); err != nil {
+		log.Fatalf("reading targets: %s", err)
+	}
+
+	src, err := generate(root, targets, *pkg, *schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the Go source of the data source constructors of the
+// targets. source is recorded in the header, so the checked-in file tells
+// which schema it was generated from.
+func generate(root map[string]interface{}, targets []target, pkg string, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by tools/schemagen from %s; DO NOT EDIT.\n\npackage %s\n\n", source, pkg)
+	fmt.Fprintf(&buf, "import \"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"\n")
+
+	for _, t := range targets {
+		if t.MaxDepth == 0 {
+			t.MaxDepth = defaultMaxDepth
+		}
+		g := &generator{root: root, target: t}
+		def, err := g.resolve(map[string]interface{}{"$ref": t.Ref})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.DataSource, err)
+		}
+		fields, err := g.objectFields(def, "", 0)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&buf, "\nfunc %s() *schema.Resource {\n", t.Func)
+		fmt.Fprintf(&buf, "\treturn NewYamlDataSource(%q, %q, []Field{\n", t.Description, t.Root)
+		writeFields(&buf, fields)
+		fmt.Fprintf(&buf, "\t})\n}\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func readJson(path string, v interface{}) error {
+	var r io.Reader
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		resp, err := http.Get(path)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: %s", path, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	return json.NewDecoder(r).Decode(v)
+}
+
+// resolve follows $ref until it reaches a definition.
+func (g *generator) resolve(s map[string]interface{}) (map[string]interface{}, error) {
+	for seen := 0; ; seen++ {
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			return s, nil
+		}
+		if seen > 32 {
+			return nil, fmt.Errorf("$ref loop at %s", ref)
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("unsupported $ref %s", ref)
+		}
+
+		var node interface{} = g.root
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			m, ok := node.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("$ref %s not found", ref)
+			}
+			if node, ok = m[part]; !ok {
+				return nil, fmt.Errorf("$ref %s not found", ref)
+			}
+		}
+		if s, ok = node.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("$ref %s is not a schema", ref)
+		}
+	}
+}
+
+// alternatives returns the schemas of a oneOf or anyOf other than the ones
+// only accepting expressions, and whether there were any of those.
+func (g *generator) alternatives(s map[string]interface{}) ([]map[string]interface{}, bool) {
+	var all []interface{}
+	if v, ok := s["oneOf"].([]interface{}); ok {
+		all = v
+	} else if v, ok := s["anyOf"].([]interface{}); ok {
+		all = v
+	} else {
+		return []map[string]interface{}{s}, false
+	}
+
+	var alts []map[string]interface{}
+	expression := false
+	for _, a := range all {
+		m, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		m, err := g.resolve(m)
+		if err != nil {
+			continue
+		}
+		if pattern, ok := m["pattern"].(string); ok && isExpressionPattern(pattern) {
+			expression = true
+			continue
+		}
+		alts = append(alts, m)
+	}
+	return alts, expression
+}
+
+func (g *generator) objectFields(s map[string]interface{}, path string, depth int) ([]field, error) {
+	properties, _ := s["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	if r, ok := s["required"].([]interface{}); ok {
+		for _, name := range r {
+			if name, ok := name.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		if !strings.HasPrefix(key, "__") && !g.excluded(join(path, key)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keyOrder(keys[i]) < keyOrder(keys[j]) })
+
+	var fields []field
+	for _, key := range keys {
+		p, ok := properties[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		f, err := g.field(p, key, join(path, key), required[key], depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", g.target.DataSource, join(path, key), err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (g *generator) field(s map[string]interface{}, key string, path string, required bool, depth int) (field, error) {
+	s, err := g.resolve(s)
+	if err != nil {
+		return field{}, err
+	}
+	f := field{
+		Name:        snakeCase(key),
+		Key:         key,
+		Required:    required,
+		Description: description(s),
+	}
+
+	alts, expression := g.alternatives(s)
+	if g.raw(path) || len(alts) != 1 {
+		f.Kind = kindYaml
+		f.List = len(alts) == 1 && schemaType(alts[0]) == "array"
+		f.Description = strings.TrimSpace(f.Description + " YAML of the `" + key + "` field.")
+		return f, nil
+	}
+	s = alts[0]
+	if f.Description == "" {
+		f.Description = description(s)
+	}
+
+	switch schemaType(s) {
+	case "string":
+		f.Kind = kindString
+		f.Enum = enum(s)
+		if len(f.Enum) == 1 && required && !expression {
+			f.Const = f.Enum[0]
+		}
+		if len(f.Enum) > 1 && expression {
+			f.Description = strings.TrimSpace(f.Description + " One of " + strings.Join(f.Enum, ", ") + ", or an expression.")
+			f.Enum = nil
+		}
+	case "boolean":
+		f.Kind = scalarKind(kindBool, expression)
+	case "integer":
+		f.Kind = scalarKind(kindInt, expression)
+	case "number":
+		f.Kind = scalarKind(kindNumber, expression)
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		if items == nil {
+			f.Kind = kindYaml
+			f.List = true
+			return f, nil
+		}
+		item, err := g.field(items, key, path, false, depth)
+		if err != nil {
+			return field{}, err
+		}
+		if item.List {
+			item.Kind = kindYaml
+		}
+		item.Name, item.Key, item.Required, item.Const = f.Name, f.Key, required, ""
+		if item.Description == "" {
+			item.Description = f.Description
+		}
+		if item.Kind == kindMap {
+			item.Kind = kindYaml
+		}
+		item.List = true
+		return item, nil
+	case "object":
+		properties, _ := s["properties"].(map[string]interface{})
+		if len(properties) == 0 {
+			if values, ok := s["additionalProperties"].(map[string]interface{}); ok && schemaType(values) == "string" {
+				f.Kind = kindMap
+			} else {
+				f.Kind = kindYaml
+			}
+			return f, nil
+		}
+		if depth >= g.target.MaxDepth {
+			f.Kind = kindYaml
+			return f, nil
+		}
+		f.Kind = kindObject
+		if f.Fields, err = g.objectFields(s, path, depth+1); err != nil {
+			return field{}, err
+		}
+	default:
+		f.Kind = kindYaml
+	}
+	return f, nil
+}
+
+func (g *generator) raw(path string) bool {
+	for _, p := range g.target.Raw {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) excluded(path string) bool {
+	for _, p := range g.target.Exclude {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpressionPattern reports whether a string pattern only accepts
+// expressions, as the alternatives Harness adds to most fields do.
+func isExpressionPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, "^<\\+") || strings.HasPrefix(pattern, "(<\\+")
+}
+
+func schemaType(s map[string]interface{}) string {
+	if t, ok := s["type"].(string); ok {
+		return t
+	}
+	if _, ok := s["properties"]; ok {
+		return "object"
+	}
+	if _, ok := s["enum"]; ok {
+		return "string"
+	}
+	return ""
+}
+
+func scalarKind(k kind, expression bool) kind {
+	if expression {
+		return kindScalar
+	}
+	return k
+}
+
+func enum(s map[string]interface{}) []string {
+	var values []string
+	if c, ok := s["const"].(string); ok {
+		return []string{c}
+	}
+	if e, ok := s["enum"].([]interface{}); ok {
+		for _, v := range e {
+			if v, ok := v.(string); ok {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func description(s map[string]interface{}) string {
+	d, _ := s["description"].(string)
+	d = strings.Join(strings.Fields(d), " ")
+	if d != "" && !strings.HasSuffix(d, ".") {
+		d += "."
+	}
+	return d
+}
+
+// keyOrder puts the fields that identify an element first, as Harness does.
+func keyOrder(key string) string {
+	switch key {
+	case "type":
+		return "0"
+	case "name":
+		return "1"
+	case "identifier":
+		return "2"
+	}
+	return "3" + key
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func writeFields(buf *bytes.Buffer, fields []field) {
+	for _, f := range fields {
+		fmt.Fprintf(buf, "{\nName: %q,\nKey: %q,\nKind: %s,\n", f.Name, f.Key, f.Kind)
+		if f.Description != "" {
+			fmt.Fprintf(buf, "Description: %q,\n", f.Description)
+		}
+		if f.Required {
+			fmt.Fprintf(buf, "Required: true,\n")
+		}
+		if f.List {
+			fmt.Fprintf(buf, "List: true,\n")
+		}
+		if len(f.Enum) > 0 {
+			fmt.Fprintf(buf, "Enum: %#v,\n", f.Enum)
+		}
+		if f.Const != "" {
+			fmt.Fprintf(buf, "Const: %q,\n", f.Const)
+		}
+		if len(f.Fields) > 0 {
+			fmt.Fprintf(buf, "Fields: []Field{\n")
+			writeFields(buf, f.Fields)
+			fmt.Fprintf(buf, "},\n")
+		}
+		fmt.Fprintf(buf, "},\n")
+	}
+}
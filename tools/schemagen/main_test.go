@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testSchema has the shapes the published pipeline schema uses: fields
+// accepting expressions through a oneOf, a `type` with a single allowed value,
+// arrays of referenced objects and maps of strings.
+const testSchema = `{
+  "definitions": {
+    "step": {
+      "type": "object",
+      "required": ["type", "identifier"],
+      "properties": {
+        "type": {"type": "string", "enum": ["ShellScript"]},
+        "identifier": {"type": "string", "description": "Identifier of the step"},
+        "__uuid": {"type": "string"},
+        "timeout": {"type": "string"},
+        "failFast": {"type": "boolean"},
+        "retries": {
+          "oneOf": [
+            {"type": "integer"},
+            {"type": "string", "pattern": "^<\\+input>.*$"}
+          ]
+        },
+        "shell": {
+          "oneOf": [
+            {"type": "string", "enum": ["Bash", "PowerShell"]},
+            {"type": "string", "pattern": "(<\\+.+>.*)"}
+          ]
+        },
+        "mode": {"type": "string", "enum": ["Fast", "Slow"]},
+        "strategy": {
+          "oneOf": [
+            {"$ref": "#/definitions/matrix"},
+            {"$ref": "#/definitions/parallelism"}
+          ]
+        },
+        "tags": {"type": "object", "additionalProperties": {"type": "string"}},
+        "variables": {"type": "array", "items": {"$ref": "#/definitions/variable"}},
+        "selectors": {"type": "array", "items": {"type": "string"}},
+        "spec": {"$ref": "#/definitions/spec"}
+      }
+    },
+    "variable": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "value": {"type": "string"}
+      }
+    },
+    "spec": {
+      "type": "object",
+      "properties": {
+        "script": {"type": "string"},
+        "nested": {
+          "type": "object",
+          "properties": {"deep": {"type": "object", "properties": {"value": {"type": "string"}}}}
+        },
+        "steps": {"type": "array", "items": {"type": "object", "properties": {"step": {"type": "string"}}}}
+      }
+    },
+    "matrix": {"type": "object", "properties": {"matrix": {"type": "string"}}},
+    "parallelism": {"type": "object", "properties": {"parallelism": {"type": "integer"}}}
+  }
+}`
+
+func TestObjectFields(t *testing.T) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(testSchema), &root); err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{root: root, target: target{
+		DataSource: "test",
+		Ref:        "#/definitions/step",
+		Raw:        []string{"spec.steps"},
+		MaxDepth:   2,
+	}}
+	def, err := g.resolve(map[string]interface{}{"$ref": g.target.Ref})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := g.objectFields(def, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byKey := map[string]field{}
+	var order []string
+	for _, f := range fields {
+		byKey[f.Key] = f
+		order = append(order, f.Key)
+	}
+
+	if order[0] != "type" || order[1] != "identifier" {
+		t.Errorf("identifying fields are not first: %v", order)
+	}
+	if _, ok := byKey["__uuid"]; ok {
+		t.Errorf("internal field __uuid was generated")
+	}
+
+	for key, want := range map[string]field{
+		"type":       {Name: "type", Kind: kindString, Required: true, Const: "ShellScript"},
+		"identifier": {Name: "identifier", Kind: kindString, Required: true, Description: "Identifier of the step."},
+		"failFast":   {Name: "fail_fast", Kind: kindBool},
+		"retries":    {Name: "retries", Kind: kindScalar},
+		"shell":      {Name: "shell", Kind: kindString, Description: "One of Bash, PowerShell, or an expression."},
+		"strategy":   {Name: "strategy", Kind: kindYaml, Description: "YAML of the `strategy` field."},
+		"tags":       {Name: "tags", Kind: kindMap},
+		"variables":  {Name: "variables", Kind: kindObject, List: true},
+		"selectors":  {Name: "selectors", Kind: kindString, List: true},
+		"spec":       {Name: "spec", Kind: kindObject},
+	} {
+		got, ok := byKey[key]
+		if !ok {
+			t.Errorf("%s: not generated", key)
+			continue
+		}
+		if got.Name != want.Name || got.Kind != want.Kind || got.Required != want.Required || got.List != want.List || got.Const != want.Const || got.Description != want.Description {
+			t.Errorf("%s: got %+v, want %+v", key, got, want)
+		}
+	}
+
+	if mode := byKey["mode"]; len(mode.Enum) != 2 || mode.Const != "" {
+		t.Errorf("mode: got %+v, want an enum of two values", mode)
+	}
+	if variables := byKey["variables"]; len(variables.Fields) != 2 {
+		t.Errorf("variables: got %+v, want the fields of the referenced object", variables)
+	}
+
+	spec := map[string]field{}
+	for _, f := range byKey["spec"].Fields {
+		spec[f.Key] = f
+	}
+	if steps := spec["steps"]; steps.Kind != kindYaml || !steps.List {
+		t.Errorf("spec.steps: got %+v, want a raw YAML list", steps)
+	}
+	nested := spec["nested"]
+	if nested.Kind != kindObject || len(nested.Fields) != 1 || nested.Fields[0].Kind != kindYaml {
+		t.Errorf("spec.nested: got %+v, want objects beyond the maximum depth as YAML", nested)
+	}
+}
+
+func TestResolveLoop(t *testing.T) {
+	g := &generator{root: map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"a": map[string]interface{}{"$ref": "#/definitions/b"},
+			"b": map[string]interface{}{"$ref": "#/definitions/a"},
+		},
+	}}
+	if _, err := g.resolve(map[string]interface{}{"$ref": "#/definitions/a"}); err == nil {
+		t.Error("expected an error for a $ref loop")
+	}
+	if _, err := g.resolve(map[string]interface{}{"$ref": "#/definitions/missing"}); err == nil {
+		t.Error("expected an error for a missing $ref")
+	}
+}
+
+// TestGenerate checks that the targets generate from the fixture schema,
+// each into a constructor of the data source.
+func TestGenerate(t *testing.T) {
+	const source = "schemagen/testdata/pipeline.json"
+
+	var root map[string]interface{}
+	if err := readJson("testdata/pipeline.json", &root); err != nil {
+		t.Fatal(err)
+	}
+	var targets []target
+	if err := readJson("targets.json", &targets); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := generate(root, targets, "pipeline_schema", source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(src), "// Code generated by tools/schemagen from "+source+"; DO NOT EDIT.") {
+		t.Errorf("missing header: %.80s", src)
+	}
+	for _, target := range targets {
+		if !strings.Contains(string(src), "func "+target.Func+"() *schema.Resource {") {
+			t.Errorf("%s: constructor %s not generated", target.DataSource, target.Func)
+		}
+	}
+}